@@ -1,35 +1,73 @@
 package kucoin
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
 // AccountHistory struct represents kucoin data model.
 type AccountHistory struct {
-	Datas []struct {
-		Fee             float64     `json:"fee"`
-		Oid             string      `json:"oid"`
-		Type            string      `json:"type"`
-		Amount          float64     `json:"amount"`
-		Remark          string      `json:"remark"`
-		Status          string      `json:"status"`
-		Address         string      `json:"address"`
-		Context         string      `json:"context"`
-		UserOid         string      `json:"userOid"`
-		CoinType        string      `json:"coinType"`
-		CreatedAt       int64       `json:"createdAt"`
-		DeletedAt       interface{} `json:"deletedAt"`
-		UpdatedAt       int64       `json:"updatedAt"`
-		OuterWalletTxid interface{} `json:"outerWalletTxid"`
-	} `json:"datas"`
-	Total           int         `json:"total"`
-	Limit           int         `json:"limit"`
-	PageNos         int         `json:"pageNos"`
-	CurrPageNo      int         `json:"currPageNo"`
-	NavigatePageNos []int       `json:"navigatePageNos"`
-	CoinType        string      `json:"coinType"`
-	Type            interface{} `json:"type"`
+	Datas           []AccountRecord `json:"datas"`
+	Total           int             `json:"total"`
+	Limit           int             `json:"limit"`
+	PageNos         int             `json:"pageNos"`
+	CurrPageNo      int             `json:"currPageNo"`
+	NavigatePageNos []int           `json:"navigatePageNos"`
+	CoinType        string          `json:"coinType"`
+	Type            interface{}     `json:"type"`
+	UserOid         string          `json:"userOid"`
+	Status          interface{}     `json:"status"`
+	FirstPage       bool            `json:"firstPage"`
+	LastPage        bool            `json:"lastPage"`
+	StartRow        int             `json:"startRow"`
+}
+
+// AccountRecord struct represents kucoin data model of deposit or withdrawal record.
+type AccountRecord struct {
+	Fee             float64     `json:"fee"`
+	Oid             string      `json:"oid"`
+	Type            string      `json:"type"`
+	Amount          float64     `json:"amount"`
+	Remark          string      `json:"remark"`
+	Status          string      `json:"status"`
+	Address         string      `json:"address"`
+	Context         string      `json:"context"`
 	UserOid         string      `json:"userOid"`
-	Status          interface{} `json:"status"`
-	FirstPage       bool        `json:"firstPage"`
-	LastPage        bool        `json:"lastPage"`
-	StartRow        int         `json:"startRow"`
+	CoinType        string      `json:"coinType"`
+	CreatedAt       int64       `json:"createdAt"`
+	DeletedAt       interface{} `json:"deletedAt"`
+	UpdatedAt       int64       `json:"updatedAt"`
+	OuterWalletTxid interface{} `json:"outerWalletTxid"`
+}
+
+// OuterTxid returns transaction id in the outer wallet, or empty string
+// if it is not known yet.
+func (r AccountRecord) OuterTxid() string {
+	if r.OuterWalletTxid == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", r.OuterWalletTxid)
+}
+
+// Confirmations returns number of network confirmations of deposit
+// if Kucoin reports it in the record context.
+func (r AccountRecord) Confirmations() (int, bool) {
+	var context map[string]interface{}
+	if err := json.Unmarshal([]byte(r.Context), &context); err != nil {
+		return 0, false
+	}
+	for _, key := range []string{"confirmations", "confirmation", "confirm"} {
+		switch v := context[key].(type) {
+		case float64:
+			return int(v), true
+		case string:
+			if n, err := strconv.Atoi(v); err == nil {
+				return n, true
+			}
+		}
+	}
+	return 0, false
 }
 
 type rawAccountHistory struct {
@@ -2,32 +2,23 @@ package kucoin
 
 // ActiveMapOrder struct represents kucoin data model.
 type ActiveMapOrder struct {
-	SELL []struct {
-		Oid           string      `json:"oid"`
-		Type          string      `json:"type"`
-		UserOid       interface{} `json:"userOid"`
-		CoinType      string      `json:"coinType"`
-		CoinTypePair  string      `json:"coinTypePair"`
-		Direction     string      `json:"direction"`
-		Price         float64     `json:"price"`
-		DealAmount    float64     `json:"dealAmount"`
-		PendingAmount float64     `json:"pendingAmount"`
-		CreatedAt     int64       `json:"createdAt"`
-		UpdatedAt     int64       `json:"updatedAt"`
-	} `json:"SELL"`
-	BUY []struct {
-		Oid           string      `json:"oid"`
-		Type          string      `json:"type"`
-		UserOid       interface{} `json:"userOid"`
-		CoinType      string      `json:"coinType"`
-		CoinTypePair  string      `json:"coinTypePair"`
-		Direction     string      `json:"direction"`
-		Price         float64     `json:"price"`
-		DealAmount    float64     `json:"dealAmount"`
-		PendingAmount float64     `json:"pendingAmount"`
-		CreatedAt     int64       `json:"createdAt"`
-		UpdatedAt     int64       `json:"updatedAt"`
-	} `json:"BUY"`
+	SELL []MapOrder `json:"SELL"`
+	BUY  []MapOrder `json:"BUY"`
+}
+
+// MapOrder struct represents kucoin data model of single order in ActiveMapOrder.
+type MapOrder struct {
+	Oid           string      `json:"oid"`
+	Type          string      `json:"type"`
+	UserOid       interface{} `json:"userOid"`
+	CoinType      string      `json:"coinType"`
+	CoinTypePair  string      `json:"coinTypePair"`
+	Direction     string      `json:"direction"`
+	Price         float64     `json:"price"`
+	DealAmount    float64     `json:"dealAmount"`
+	PendingAmount float64     `json:"pendingAmount"`
+	CreatedAt     int64       `json:"createdAt"`
+	UpdatedAt     int64       `json:"updatedAt"`
 }
 
 type rawActiveMapOrder struct {
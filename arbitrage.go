@@ -0,0 +1,146 @@
+package kucoin
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// PairEdge is conversion of From coin to To coin through Symbol.
+// Side is the order side used: SELL of base coin at best bid,
+// or BUY of base coin at best ask.
+type PairEdge struct {
+	From    string
+	To      string
+	Symbol  string
+	Side    string
+	Price   float64
+	FeeRate float64
+	// Rate is the amount of To coin received for one From coin, fee excluded.
+	Rate float64
+}
+
+// NetRate returns Rate adjusted by fee.
+func (e PairEdge) NetRate() float64 {
+	return e.Rate * (1 - e.FeeRate)
+}
+
+// PairGraph is a graph of coins connected by trading pairs.
+type PairGraph struct {
+	edges map[string]map[string]PairEdge
+}
+
+// NewPairGraph builds graph of trading symbols with both Buy and Sell prices.
+func NewPairGraph(symbols []Symbol) *PairGraph {
+	g := &PairGraph{edges: make(map[string]map[string]PairEdge)}
+	for _, s := range symbols {
+		if !s.Trading || s.Buy <= 0 || s.Sell <= 0 {
+			continue
+		}
+		base, quote := strings.ToUpper(s.CoinType), strings.ToUpper(s.CoinTypePair)
+		symbol := normalizeSymbol(s.Symbol)
+		g.add(PairEdge{From: base, To: quote, Symbol: symbol, Side: "SELL", Price: s.Buy, FeeRate: s.FeeRate, Rate: s.Buy})
+		g.add(PairEdge{From: quote, To: base, Symbol: symbol, Side: "BUY", Price: s.Sell, FeeRate: s.FeeRate, Rate: 1 / s.Sell})
+	}
+	return g
+}
+
+func (g *PairGraph) add(e PairEdge) {
+	if g.edges[e.From] == nil {
+		g.edges[e.From] = make(map[string]PairEdge)
+	}
+	g.edges[e.From][e.To] = e
+}
+
+// Edge returns conversion of from coin to to coin.
+func (g *PairGraph) Edge(from, to string) (PairEdge, bool) {
+	e, ok := g.edges[strings.ToUpper(from)][strings.ToUpper(to)]
+	return e, ok
+}
+
+// Coins returns all coins of the graph sorted by name.
+func (g *PairGraph) Coins() []string {
+	coins := make([]string, 0, len(g.edges))
+	for c := range g.edges {
+		coins = append(coins, c)
+	}
+	sort.Strings(coins)
+	return coins
+}
+
+// Triangle is three-leg conversion cycle, e.g. BTC -> ETH -> KCS -> BTC.
+type Triangle struct {
+	Legs [3]PairEdge
+	// Return is fee-adjusted profit of the cycle, e.g. 0.01 for 1%.
+	Return float64
+}
+
+// Start returns coin the cycle starts and ends with.
+func (t Triangle) Start() string {
+	return t.Legs[0].From
+}
+
+// Triangles enumerates all three-leg cycles of the graph. Each cycle is
+// reported once per direction, starting from alphabetically smallest coin.
+func (g *PairGraph) Triangles() (triangles []Triangle) {
+	for _, a := range g.Coins() {
+		for b, ab := range g.edges[a] {
+			if b <= a {
+				continue
+			}
+			for c, bc := range g.edges[b] {
+				if c <= a || c == b {
+					continue
+				}
+				ca, ok := g.edges[c][a]
+				if !ok {
+					continue
+				}
+				t := Triangle{Legs: [3]PairEdge{ab, bc, ca}}
+				t.Return = ab.NetRate()*bc.NetRate()*ca.NetRate() - 1
+				triangles = append(triangles, t)
+			}
+		}
+	}
+	return
+}
+
+// ScanTriangles returns cycles of symbols with return not less than minReturn,
+// the most profitable first.
+func ScanTriangles(symbols []Symbol, minReturn float64) (triangles []Triangle) {
+	for _, t := range NewPairGraph(symbols).Triangles() {
+		if t.Return >= minReturn {
+			triangles = append(triangles, t)
+		}
+	}
+	sort.Slice(triangles, func(i, j int) bool {
+		return triangles[i].Return > triangles[j].Return
+	})
+	return
+}
+
+// ExecutableSize is used to get the amount of the start coin which can be
+// converted through triangle at the best price levels of the orders books.
+func (b *Kucoin) ExecutableSize(t Triangle) (size float64, err error) {
+	size = math.Inf(1)
+	// scale is the amount of leg From coin received per one start coin.
+	scale := 1.0
+	for _, leg := range t.Legs {
+		var top TopOfBook
+		if top, err = b.TopOfBook(leg.Symbol); err != nil {
+			return 0, err
+		}
+		var capacity float64
+		if leg.Side == "SELL" {
+			capacity = top.BidAmount
+		} else {
+			capacity = top.AskAmount * top.Ask
+		}
+		size = math.Min(size, capacity/scale)
+		scale *= leg.NetRate()
+	}
+	if math.IsInf(size, 1) {
+		size = 0
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package kucoin
+
+import (
+	"net/http"
+	"testing"
+)
+
+// triangleSymbols returns KCS-BTC, ETH-BTC and KCS-ETH symbols where
+// BTC -> KCS -> ETH -> BTC cycle is profitable and the reverse one is not.
+func triangleSymbols() []Symbol {
+	return []Symbol{
+		{Symbol: "KCS-BTC", CoinType: "KCS", CoinTypePair: "BTC", Trading: true, Buy: 0.0002, Sell: 0.00021, FeeRate: 0.001},
+		{Symbol: "ETH-BTC", CoinType: "ETH", CoinTypePair: "BTC", Trading: true, Buy: 0.05, Sell: 0.051, FeeRate: 0.001},
+		{Symbol: "KCS-ETH", CoinType: "KCS", CoinTypePair: "ETH", Trading: true, Buy: 0.0045, Sell: 0.0046, FeeRate: 0.001},
+		{Symbol: "NEO-BTC", CoinType: "NEO", CoinTypePair: "BTC", Trading: false, Buy: 0.002, Sell: 0.0021},
+	}
+}
+
+func trianglePath(t Triangle) string {
+	return t.Legs[0].From + ">" + t.Legs[1].From + ">" + t.Legs[2].From + ">" + t.Legs[2].To
+}
+
+func TestTriangles(t *testing.T) {
+	triangles := NewPairGraph(triangleSymbols()).Triangles()
+	found := map[string]int{}
+	for _, tr := range triangles {
+		found[trianglePath(tr)]++
+	}
+	if len(triangles) != 2 || found["BTC>KCS>ETH>BTC"] != 1 || found["BTC>ETH>KCS>BTC"] != 1 {
+		t.Fatalf("triangles = %v, want each direction once", found)
+	}
+
+	fee := 0.999 * 0.999 * 0.999
+	want := map[string]float64{
+		// Buy KCS at ask, sell KCS for ETH at bid, sell ETH at bid.
+		"BTC>KCS>ETH>BTC": 1/0.00021*0.0045*0.05*fee - 1,
+		// Buy ETH at ask, buy KCS for ETH at ask, sell KCS at bid.
+		"BTC>ETH>KCS>BTC": 1/0.051/0.0046*0.0002*fee - 1,
+	}
+	for _, tr := range triangles {
+		if path := trianglePath(tr); !almostEqual(tr.Return, want[path]) {
+			t.Errorf("%s return = %v, want %v", path, tr.Return, want[path])
+		}
+	}
+}
+
+func TestScanTriangles(t *testing.T) {
+	triangles := ScanTriangles(triangleSymbols(), 0)
+	if len(triangles) != 1 || trianglePath(triangles[0]) != "BTC>KCS>ETH>BTC" {
+		t.Fatalf("profitable triangles = %+v, want BTC>KCS>ETH>BTC only", triangles)
+	}
+	triangles = ScanTriangles(triangleSymbols(), -1)
+	if len(triangles) != 2 || triangles[0].Return < triangles[1].Return {
+		t.Errorf("triangles = %+v, want both, the most profitable first", triangles)
+	}
+}
+
+func TestExecutableSize(t *testing.T) {
+	books := map[string]string{
+		"KCS-BTC": `{"SELL":[[0.00021,100,0.021]],"BUY":[[0.0002,100,0.02]]}`,
+		"KCS-ETH": `{"SELL":[[0.0046,80,0.368]],"BUY":[[0.0045,50,0.225]]}`,
+		"ETH-BTC": `{"SELL":[[0.051,10,0.51]],"BUY":[[0.05,10,0.5]]}`,
+	}
+	k := newTestKucoin(func(r *http.Request) string {
+		return `{"success":true,"code":"OK","data":` + books[r.URL.Query().Get("symbol")] + `}`
+	})
+	triangles := ScanTriangles(triangleSymbols(), 0)
+	if len(triangles) != 1 {
+		t.Fatalf("triangles = %+v, want one", triangles)
+	}
+	size, err := k.ExecutableSize(triangles[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// KCS-ETH bid of 50 KCS limits the cycle: 0.021 BTC of KCS-BTC ask buys
+	// 99.9 KCS after fee, 50 KCS are bought for 50*0.00021/0.999 BTC.
+	if want := 50 * 0.00021 / 0.999; !almostEqual(size, want) {
+		t.Errorf("size = %v, want %v", size, want)
+	}
+}
@@ -0,0 +1,122 @@
+package backtest
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eeonevision/kucoin-go"
+)
+
+// Config defines initial state and simulation parameters.
+type Config struct {
+	// Balances are initial balances by coin.
+	Balances map[string]float64
+	// Quote is the coin equity is measured in, e.g. BTC.
+	Quote string
+	// Latency is the delay of order creation and cancellation.
+	Latency time.Duration
+	// FeeRate is used for symbols which fee rate is not known from tickers.
+	FeeRate float64
+}
+
+// Strategy reacts on market data events by placing and cancelling orders.
+// It receives kucoin.Trader, so the same strategy can be run against
+// Kucoin client or kucoin.PaperTrader. Market data of replayed events
+// is available through OrdersBook and GetSymbol of the trader.
+type Strategy interface {
+	OnEvent(t kucoin.Trader, e Event)
+}
+
+// StrategyFunc adapts function to Strategy.
+type StrategyFunc func(t kucoin.Trader, e Event)
+
+// OnEvent implements Strategy.
+func (f StrategyFunc) OnEvent(t kucoin.Trader, e Event) {
+	f(t, e)
+}
+
+// EquityPoint is the value of all balances at time.
+type EquityPoint struct {
+	Time   time.Time
+	Equity float64
+}
+
+// Report is the result of backtest.
+type Report struct {
+	Equity []EquityPoint
+	// Deals are simulated executions in chronological order.
+	Deals       []kucoin.DealtOrder
+	Balances    map[string]float64
+	StartEquity float64
+	FinalEquity float64
+	// Return is FinalEquity relative to StartEquity, e.g. 0.1 for 10%.
+	Return float64
+	// MaxDrawdown is the largest relative decline of equity from its peak.
+	MaxDrawdown float64
+	// Unpriced lists held coins which had no route to Quote during the whole
+	// backtest. They are excluded from equity.
+	Unpriced []string
+}
+
+// Run replays events sorted by time through strategy and returns the report.
+// For each event pending cancellations are processed first, then orders are
+// matched against the event and finally strategy is called.
+//
+// Equity is valued by the last known prices. Coins held before their first
+// price is known are valued at that first price, so equity doesn't jump when
+// markets appear in data. Coins without current price are carried at their
+// last known value.
+func Run(events []Event, cfg Config, s Strategy) Report {
+	quote := strings.ToUpper(cfg.Quote)
+	x := newExchange(cfg)
+	var report Report
+	// pending holds amounts of coins which were not priced yet at each point.
+	pending := map[int]map[string]float64{}
+	for _, e := range events {
+		x.advance(e.Time)
+		x.apply(e)
+		s.OnEvent(x, e)
+
+		equity, unpriced := x.equity(quote)
+		if len(unpriced) > 0 {
+			pending[len(report.Equity)] = unpriced
+		}
+		report.Equity = append(report.Equity, EquityPoint{Time: x.now, Equity: equity})
+	}
+
+	unpriced := map[string]bool{}
+	for i, coins := range pending {
+		for coin, amount := range coins {
+			if v, ok := x.firstValues[coin]; ok {
+				report.Equity[i].Equity += amount * v
+			} else {
+				unpriced[coin] = true
+			}
+		}
+	}
+	for coin := range unpriced {
+		report.Unpriced = append(report.Unpriced, coin)
+	}
+	sort.Strings(report.Unpriced)
+
+	var peak float64
+	for _, p := range report.Equity {
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		if peak > 0 && (peak-p.Equity)/peak > report.MaxDrawdown {
+			report.MaxDrawdown = (peak - p.Equity) / peak
+		}
+	}
+	report.Deals = x.Deals()
+	report.Balances = x.Balances()
+	if n := len(report.Equity); n > 0 {
+		report.StartEquity = report.Equity[0].Equity
+		report.FinalEquity = report.Equity[n-1].Equity
+		if report.StartEquity != 0 {
+			report.Return = report.FinalEquity/report.StartEquity - 1
+		}
+	}
+	return report
+}
@@ -0,0 +1,110 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/eeonevision/kucoin-go"
+)
+
+func TestRun(t *testing.T) {
+	t0 := time.Unix(1500000000, 0)
+	events := []Event{
+		{Time: t0, Symbol: "KCS-BTC", Book: &kucoin.OrdersBook{
+			BUY: [][]float64{{0.9, 10, 9}}, SELL: [][]float64{{1.1, 10, 11}}}},
+		{Time: t0.Add(time.Second), Symbol: "KCS-BTC", Trade: &kucoin.Trade{Price: 0.95, Amount: 5}},
+		{Time: t0.Add(2 * time.Second), Symbol: "KCS-BTC", Book: &kucoin.OrdersBook{
+			BUY: [][]float64{{1.2, 10, 12}}, SELL: [][]float64{{1.3, 10, 13}}}},
+	}
+	cfg := Config{Balances: map[string]float64{"BTC": 1}, Quote: "BTC", FeeRate: 0}
+
+	var placed bool
+	strategy := StrategyFunc(func(tr kucoin.Trader, e Event) {
+		book, err := tr.OrdersBook(e.Symbol, 0, 1)
+		if err != nil || placed {
+			return
+		}
+		placed = true
+		if book.BUY[0][0] != 0.9 {
+			t.Errorf("best bid = %v, want 0.9", book.BUY[0][0])
+		}
+		if _, err = tr.CreateOrder(e.Symbol, "BUY", 0.95, 1); err != nil {
+			t.Error(err)
+		}
+	})
+	report := Run(events, cfg, strategy)
+
+	if len(report.Deals) != 1 || report.Deals[0].DealPrice != 0.95 || report.Deals[0].Amount != 1 {
+		t.Fatalf("deals = %+v, want 1 KCS at 0.95", report.Deals)
+	}
+	if report.Balances["KCS"] != 1 || math.Abs(report.Balances["BTC"]-0.05) > 1e-9 {
+		t.Errorf("balances = %v", report.Balances)
+	}
+	if want := 0.05 + 1.25; math.Abs(report.FinalEquity-want) > 1e-9 {
+		t.Errorf("FinalEquity = %v, want %v", report.FinalEquity, want)
+	}
+}
+
+func book(bid, ask float64) *kucoin.OrdersBook {
+	return &kucoin.OrdersBook{BUY: [][]float64{{bid, 1, bid}}, SELL: [][]float64{{ask, 1, ask}}}
+}
+
+func TestRunEquity(t *testing.T) {
+	t0 := time.Unix(1500000000, 0)
+	idle := StrategyFunc(func(kucoin.Trader, Event) {})
+	tests := []struct {
+		name     string
+		balances map[string]float64
+		events   []Event
+		start    float64
+		final    float64
+		unpriced []string
+	}{
+		{
+			name:     "price appears after the first event",
+			balances: map[string]float64{"BTC": 1, "KCS": 100},
+			events: []Event{
+				{Time: t0, Symbol: "ETH-BTC", Book: book(0.09, 0.11)},
+				{Time: t0.Add(time.Second), Symbol: "KCS-BTC", Book: book(0.009, 0.011)},
+			},
+			start: 2,
+			final: 2,
+		},
+		{
+			name:     "coin priced through intermediate market",
+			balances: map[string]float64{"BTC": 1, "KCS": 100},
+			events: []Event{
+				{Time: t0, Symbol: "ETH-BTC", Book: book(0.09, 0.11)},
+				{Time: t0.Add(time.Second), Symbol: "KCS-ETH", Book: book(0.09, 0.11)},
+				{Time: t0.Add(2 * time.Second), Symbol: "ETH-BTC", Book: book(0.19, 0.21)},
+			},
+			start: 2,
+			final: 3,
+		},
+		{
+			name:     "coin without route is reported",
+			balances: map[string]float64{"BTC": 1, "NEO": 5},
+			events: []Event{
+				{Time: t0, Symbol: "ETH-BTC", Book: book(0.09, 0.11)},
+			},
+			start:    1,
+			final:    1,
+			unpriced: []string{"NEO"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Run(tt.events, Config{Balances: tt.balances, Quote: "BTC"}, idle)
+			if math.Abs(report.StartEquity-tt.start) > 1e-9 || math.Abs(report.FinalEquity-tt.final) > 1e-9 {
+				t.Errorf("equity = %v .. %v, want %v .. %v", report.StartEquity, report.FinalEquity, tt.start, tt.final)
+			}
+			if tt.start == tt.final && (report.Return != 0 || report.MaxDrawdown != 0) {
+				t.Errorf("Return = %v, MaxDrawdown = %v, want 0", report.Return, report.MaxDrawdown)
+			}
+			if len(report.Unpriced) != len(tt.unpriced) || (len(tt.unpriced) > 0 && report.Unpriced[0] != tt.unpriced[0]) {
+				t.Errorf("Unpriced = %v, want %v", report.Unpriced, tt.unpriced)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Package backtest replays recorded Kucoin market data and simulates
+// order execution for offline testing of trading strategies.
+package backtest
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/eeonevision/kucoin-go"
+)
+
+// Event is a single recorded market data update of symbol.
+// Exactly one of Ticker, Book and Trade is set.
+type Event struct {
+	Time   time.Time          `json:"time"`
+	Symbol string             `json:"symbol"`
+	Ticker *kucoin.Symbol     `json:"ticker,omitempty"`
+	Book   *kucoin.OrdersBook `json:"book,omitempty"`
+	Trade  *kucoin.Trade      `json:"trade,omitempty"`
+}
+
+// Recorder writes events as JSON Lines, so they can be replayed with LoadEvents.
+type Recorder struct {
+	enc *json.Encoder
+}
+
+// NewRecorder returns Recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Record writes event.
+func (r *Recorder) Record(e Event) error {
+	return r.enc.Encode(e)
+}
+
+// RecordTicker writes ticker of symbol taken at time t.
+func (r *Recorder) RecordTicker(t time.Time, s kucoin.Symbol) error {
+	return r.Record(Event{Time: t, Symbol: s.Symbol, Ticker: &s})
+}
+
+// RecordBook writes orders book of symbol taken at time t.
+func (r *Recorder) RecordBook(t time.Time, symbol string, book kucoin.OrdersBook) error {
+	return r.Record(Event{Time: t, Symbol: symbol, Book: &book})
+}
+
+// RecordTrade writes public trade of symbol.
+func (r *Recorder) RecordTrade(symbol string, trade kucoin.Trade) error {
+	return r.Record(Event{Time: trade.Time, Symbol: symbol, Trade: &trade})
+}
+
+// LoadEvents reads JSON Lines events written by Recorder and sorts them by time.
+func LoadEvents(r io.Reader) (events []Event, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Event
+		if err = json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return
+}
@@ -0,0 +1,164 @@
+package backtest
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eeonevision/kucoin-go"
+)
+
+// Exchange simulates Kucoin over replayed market data. It implements
+// kucoin.Trader with the same matching engine as kucoin.PaperTrader,
+// so strategy code runs unchanged in backtest, paper and live trading.
+// Orders are filled against each book snapshot and trade independently,
+// liquidity consumed by simulated fills is not removed from recorded data.
+type Exchange struct {
+	*kucoin.Simulator
+	*kucoin.ReplayMarket
+
+	now     time.Time
+	feeRate float64
+	prices  map[string]float64
+	// firstValues and lastValues are the first and the last known values
+	// of one coin in quote currency of equity.
+	firstValues map[string]float64
+	lastValues  map[string]float64
+}
+
+var _ kucoin.Trader = (*Exchange)(nil)
+
+func newExchange(cfg Config) *Exchange {
+	x := &Exchange{
+		Simulator:    kucoin.NewSimulator("backtest", cfg.Balances),
+		ReplayMarket: kucoin.NewReplayMarket(),
+		feeRate:      cfg.FeeRate,
+		prices:       make(map[string]float64),
+		firstValues:  make(map[string]float64),
+		lastValues:   make(map[string]float64),
+	}
+	x.Simulator.FeeRate = cfg.FeeRate
+	x.Simulator.Latency = cfg.Latency
+	x.Simulator.SetClock(x.Now)
+	return x
+}
+
+// Now returns current simulation time.
+func (x *Exchange) Now() time.Time {
+	return x.now
+}
+
+// LastPrice returns the last known price of symbol.
+func (x *Exchange) LastPrice(symbol string) float64 {
+	return x.prices[normalize(symbol)]
+}
+
+// advance moves simulation time and processes cancellations which reached the exchange.
+func (x *Exchange) advance(t time.Time) {
+	if t.After(x.now) {
+		x.now = t
+	}
+	x.ProcessCancellations()
+}
+
+// apply updates market state with event and matches active orders against it.
+func (x *Exchange) apply(e Event) {
+	symbol := normalize(e.Symbol)
+	switch {
+	case e.Ticker != nil:
+		x.prices[symbol] = e.Ticker.LastDealPrice
+		rate := e.Ticker.FeeRate
+		if rate == 0 {
+			rate = x.feeRate
+		}
+		x.SetFeeRate(symbol, rate)
+		x.SetSymbol(*e.Ticker)
+	case e.Book != nil:
+		if len(e.Book.BUY) > 0 && len(e.Book.SELL) > 0 && len(e.Book.BUY[0]) > 0 && len(e.Book.SELL[0]) > 0 {
+			x.prices[symbol] = (e.Book.BUY[0][0] + e.Book.SELL[0][0]) / 2
+		}
+		x.SetOrdersBook(symbol, *e.Book)
+		x.MatchBook(symbol, *e.Book)
+	case e.Trade != nil:
+		x.prices[symbol] = e.Trade.Price
+		x.MatchTrade(symbol, *e.Trade)
+	}
+}
+
+// equity values balances in quote coin by the last known prices.
+// Coin without current price is carried at its last known value.
+// Coins which have never been priced are returned in unpriced with their amounts.
+func (x *Exchange) equity(quote string) (total float64, unpriced map[string]float64) {
+	for coin, amount := range x.Balances() {
+		if coin == quote {
+			total += amount
+			continue
+		}
+		if amount == 0 {
+			continue
+		}
+		if v, ok := x.unitValue(coin, quote); ok {
+			x.lastValues[coin] = v
+			if _, ok := x.firstValues[coin]; !ok {
+				x.firstValues[coin] = v
+			}
+		}
+		if v, ok := x.lastValues[coin]; ok {
+			total += amount * v
+			continue
+		}
+		if unpriced == nil {
+			unpriced = make(map[string]float64)
+		}
+		unpriced[coin] = amount
+	}
+	return
+}
+
+// unitValue returns value of one coin in quote by the last known prices,
+// through direct or inverse market, or through one intermediate coin.
+func (x *Exchange) unitValue(coin, quote string) (float64, bool) {
+	if v, ok := x.directValue(coin, quote); ok {
+		return v, true
+	}
+	symbols := make([]string, 0, len(x.prices))
+	for symbol := range x.prices {
+		symbols = append(symbols, symbol)
+	}
+	// Routes are tried in the same order on every call.
+	sort.Strings(symbols)
+	for _, symbol := range symbols {
+		pair, err := kucoin.ParsePair(symbol)
+		if err != nil || (pair.Base != coin && pair.Quote != coin) {
+			continue
+		}
+		via := pair.Quote
+		if pair.Quote == coin {
+			via = pair.Base
+		}
+		if v, ok := x.directValue(coin, via); ok {
+			if w, ok := x.directValue(via, quote); ok {
+				return v * w, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// directValue returns value of one coin in quote by price of COIN-QUOTE or QUOTE-COIN market.
+func (x *Exchange) directValue(coin, quote string) (float64, bool) {
+	if p := x.prices[coin+"-"+quote]; p > 0 {
+		return p, true
+	}
+	if p := x.prices[quote+"-"+coin]; p > 0 {
+		return 1 / p, true
+	}
+	return 0, false
+}
+
+func normalize(symbol string) string {
+	if p, err := kucoin.ParsePair(symbol); err == nil {
+		return p.String()
+	}
+	return strings.ToUpper(symbol)
+}
@@ -0,0 +1,100 @@
+package kucoin
+
+import (
+	"fmt"
+	"time"
+)
+
+// KlineInterval is the duration of single candle.
+type KlineInterval string
+
+// Supported candle intervals.
+const (
+	Interval1Min  KlineInterval = "1min"
+	Interval5Min  KlineInterval = "5min"
+	Interval15Min KlineInterval = "15min"
+	Interval30Min KlineInterval = "30min"
+	Interval1Hour KlineInterval = "1hour"
+	Interval8Hour KlineInterval = "8hour"
+	Interval1Day  KlineInterval = "1day"
+	Interval1Week KlineInterval = "1week"
+)
+
+var klineIntervals = map[KlineInterval]struct {
+	resolution string
+	duration   time.Duration
+}{
+	Interval1Min:  {"1", time.Minute},
+	Interval5Min:  {"5", 5 * time.Minute},
+	Interval15Min: {"15", 15 * time.Minute},
+	Interval30Min: {"30", 30 * time.Minute},
+	Interval1Hour: {"60", time.Hour},
+	Interval8Hour: {"480", 8 * time.Hour},
+	Interval1Day:  {"D", 24 * time.Hour},
+	Interval1Week: {"W", 7 * 24 * time.Hour},
+}
+
+// Duration returns duration of the interval, or zero for unknown interval.
+func (i KlineInterval) Duration() time.Duration {
+	return klineIntervals[i].duration
+}
+
+func (i KlineInterval) resolution() (string, error) {
+	v, ok := klineIntervals[i]
+	if !ok {
+		return "", fmt.Errorf("Unknown kline interval %q", string(i))
+	}
+	return v.resolution, nil
+}
+
+// Candle represents OHLCV data of single interval.
+type Candle struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// rawKlines is the response of chart history in TradingView format.
+type rawKlines struct {
+	Status string    `json:"s"`
+	ErrMsg string    `json:"errmsg"`
+	Time   []int64   `json:"t"`
+	Open   []float64 `json:"o"`
+	High   []float64 `json:"h"`
+	Low    []float64 `json:"l"`
+	Close  []float64 `json:"c"`
+	Volume []float64 `json:"v"`
+}
+
+// candles returns candles of response. "no_data" status means range without deals.
+func (r rawKlines) candles() ([]Candle, error) {
+	switch r.Status {
+	case "ok":
+	case "no_data":
+		return nil, nil
+	default:
+		if len(r.ErrMsg) > 0 {
+			return nil, fmt.Errorf("Klines error: %s", r.ErrMsg)
+		}
+		return nil, fmt.Errorf("Klines error: unexpected status %q", r.Status)
+	}
+	n := len(r.Time)
+	if len(r.Open) != n || len(r.High) != n || len(r.Low) != n || len(r.Close) != n || len(r.Volume) != n {
+		return nil, fmt.Errorf("Malformed klines response")
+	}
+	candles := make([]Candle, n)
+	for i := range candles {
+		candles[i] = Candle{
+			Time:   time.Unix(r.Time[i], 0),
+			Open:   r.Open[i],
+			High:   r.High[i],
+			Low:    r.Low[i],
+			Close:  r.Close[i],
+			Volume: r.Volume[i],
+		}
+	}
+	return candles, nil
+}
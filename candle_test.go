@@ -0,0 +1,94 @@
+package kucoin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRawKlinesCandles(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     rawKlines
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			raw: rawKlines{Status: "ok", Time: []int64{60, 120}, Open: []float64{1, 2}, High: []float64{2, 3},
+				Low: []float64{1, 1}, Close: []float64{2, 2}, Volume: []float64{10, 20}},
+			want: 2,
+		},
+		{name: "no data", raw: rawKlines{Status: "no_data"}, want: 0},
+		{name: "error", raw: rawKlines{Status: "error", ErrMsg: "unsupported resolution"}, wantErr: true},
+		{name: "unknown status", raw: rawKlines{Status: ""}, wantErr: true},
+		{
+			name: "mismatched lengths",
+			raw: rawKlines{Status: "ok", Time: []int64{60, 120}, Open: []float64{1, 2}, High: []float64{2, 3},
+				Low: []float64{1}, Close: []float64{2, 2}, Volume: []float64{10, 20}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candles, err := tt.raw.candles()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(candles) != tt.want {
+				t.Errorf("got %d candles, want %d", len(candles), tt.want)
+			}
+		})
+	}
+	candles, _ := tests[0].raw.candles()
+	if c := candles[1]; !c.Time.Equal(time.Unix(120, 0)) || c.Open != 2 || c.High != 3 || c.Low != 1 || c.Close != 2 || c.Volume != 20 {
+		t.Errorf("candle = %+v", c)
+	}
+}
+
+func TestGetKlines(t *testing.T) {
+	from := time.Unix(0, 0)
+	to := from.Add(time.Duration(maxKlinesPerRequest+10) * time.Minute)
+	var requests int
+	k := newTestKucoin(func(r *http.Request) string {
+		requests++
+		q := r.URL.Query()
+		start, _ := strconv.ParseInt(q.Get("from"), 10, 64)
+		end, _ := strconv.ParseInt(q.Get("to"), 10, 64)
+		// Chart history includes candle at "to" bound, so chunks overlap by one candle.
+		var ts, vs string
+		for sec := start; sec <= end; sec += 60 {
+			if len(ts) > 0 {
+				ts, vs = ts+",", vs+","
+			}
+			ts += fmt.Sprint(sec)
+			vs += "1"
+		}
+		return fmt.Sprintf(`{"s":"ok","t":[%s],"o":[%s],"h":[%s],"l":[%s],"c":[%s],"v":[%s]}`, ts, vs, vs, vs, vs, vs)
+	})
+	candles, err := k.GetKlines(context.Background(), "KCS-BTC", Interval1Min, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("sent %d requests, want 2", requests)
+	}
+	if len(candles) != maxKlinesPerRequest+10 {
+		t.Fatalf("got %d candles, want %d", len(candles), maxKlinesPerRequest+10)
+	}
+	for i, c := range candles {
+		if want := from.Add(time.Duration(i) * time.Minute); !c.Time.Equal(want) {
+			t.Fatalf("candle %d time = %v, want %v", i, c.Time, want)
+		}
+	}
+
+	k = newTestKucoin(func(r *http.Request) string {
+		return `{"s":"error","errmsg":"rate limit"}`
+	})
+	if _, err = k.GetKlines(context.Background(), "KCS-BTC", Interval1Min, from, to); err == nil {
+		t.Error("error status is returned as empty range")
+	}
+}
@@ -1,6 +1,7 @@
 package kucoin
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	b64 "encoding/base64"
@@ -71,6 +72,11 @@ func (c client) dumpResponse(r *http.Response) {
 		  e.g. amount=10&price=1.1&type=BUY
 */
 func (c *client) do(method, resource string, payload map[string]string, authNeeded bool) ([]byte, error) {
+	return c.doContext(context.Background(), method, resource, payload, authNeeded)
+}
+
+// doContext is the same as do, but request is bound to ctx.
+func (c *client) doContext(ctx context.Context, method, resource string, payload map[string]string, authNeeded bool) ([]byte, error) {
 	var req *http.Request
 
 	Url, err := url.Parse(kucoinUrl)
@@ -85,7 +91,7 @@ func (c *client) do(method, resource string, payload map[string]string, authNeed
 			q.Set(key, value)
 		}
 		Url.RawQuery = q.Encode()
-		req, err = http.NewRequest("GET", Url.String(), nil)
+		req, err = http.NewRequestWithContext(ctx, "GET", Url.String(), nil)
 		queryString = Url.Query().Encode()
 	} else {
 		postValues := url.Values{}
@@ -93,8 +99,8 @@ func (c *client) do(method, resource string, payload map[string]string, authNeed
 			postValues.Set(key, value)
 		}
 		queryString = postValues.Encode()
-		req, err = http.NewRequest(
-			method, Url.String(), strings.NewReader(
+		req, err = http.NewRequestWithContext(
+			ctx, method, Url.String(), strings.NewReader(
 				queryString,
 			),
 		)
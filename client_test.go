@@ -0,0 +1,28 @@
+package kucoin
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// newTestKucoin returns Kucoin which sends requests to handler instead of network.
+// Handler returns JSON body of response with status 200.
+func newTestKucoin(handler func(r *http.Request) string) *Kucoin {
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(handler(r))),
+			Request:    r,
+		}, nil
+	})
+	return NewCustomClient("key", "secret", http.Client{Transport: transport})
+}
@@ -1,16 +1,37 @@
 package kucoin
 
 // CoinBalance struct represents kucoin data model.
+// Balance is the free amount of coin, FreezeBalance is the amount frozen
+// by active orders and withdrawals on top of it.
 type CoinBalance struct {
 	CoinType      string  `json:"coinType"`
 	Balance       float64 `json:"balance"`
 	FreezeBalance float64 `json:"freezeBalance"`
 }
 
+// Available returns balance which is not frozen by active orders or withdrawals.
+func (c CoinBalance) Available() float64 {
+	return c.Balance
+}
+
+// Total returns the whole amount of coin held, including frozen balance.
+func (c CoinBalance) Total() float64 {
+	return c.Balance + c.FreezeBalance
+}
+
+// CoinBalances struct represents kucoin data model.
+type CoinBalances struct {
+	Datas      []CoinBalance `json:"datas"`
+	Total      int           `json:"total"`
+	Limit      int           `json:"limit"`
+	PageNos    int           `json:"pageNos"`
+	CurrPageNo int           `json:"currPageNo"`
+}
+
 type rawCoinBalances struct {
-	Success bool          `json:"success"`
-	Code    string        `json:"code"`
-	Data    []CoinBalance `json:"data"`
+	Success bool         `json:"success"`
+	Code    string       `json:"code"`
+	Data    CoinBalances `json:"data"`
 }
 
 type rawCoinBalance struct {
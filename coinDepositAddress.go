@@ -1,5 +1,11 @@
 package kucoin
 
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
 // CoinDepositAddress struct represents kucoin data model.
 type CoinDepositAddress struct {
 	Oid            string      `json:"oid"`
@@ -13,6 +19,66 @@ type CoinDepositAddress struct {
 	LastReceivedAt int64       `json:"lastReceivedAt"`
 }
 
+// Memo returns memo (tag) which must accompany deposits of the coin
+// to this address, or empty string if coin doesn't use memo.
+// Context may hold memo itself or an object with memo, tag or paymentId key.
+func (a CoinDepositAddress) Memo() string {
+	return contextMemo(a.Context)
+}
+
+func contextMemo(context interface{}) string {
+	switch c := context.(type) {
+	case string:
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(c), &object); err == nil {
+			return contextMemo(object)
+		}
+		return c
+	case float64:
+		// Numeric tags, e.g. XRP destination tag, are printed without exponent.
+		return strconv.FormatFloat(c, 'f', -1, 64)
+	case map[string]interface{}:
+		for _, key := range []string{"memo", "tag", "paymentId"} {
+			if v, ok := c[key]; ok {
+				return contextMemo(v)
+			}
+		}
+	}
+	return ""
+}
+
+// DepositAddress is a typed view of CoinDepositAddress.
+type DepositAddress struct {
+	Coin      string    `json:"coin"`
+	Address   string    `json:"address"`
+	Memo      string    `json:"memo,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// LastReceivedAt is nil if address has not received deposits yet.
+	LastReceivedAt *time.Time `json:"lastReceivedAt,omitempty"`
+}
+
+// Typed converts CoinDepositAddress to DepositAddress.
+func (a CoinDepositAddress) Typed() DepositAddress {
+	d := DepositAddress{
+		Coin:      a.CoinType,
+		Address:   a.Address,
+		Memo:      a.Memo(),
+		CreatedAt: msToTime(a.CreatedAt),
+		UpdatedAt: msToTime(a.UpdatedAt),
+	}
+	if a.LastReceivedAt > 0 {
+		t := msToTime(a.LastReceivedAt)
+		d.LastReceivedAt = &t
+	}
+	return d
+}
+
+// msToTime converts Kucoin timestamp in milliseconds to time.
+func msToTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
 type rawCoinDepositAddress struct {
 	Data CoinDepositAddress `json:"data"`
 }
@@ -0,0 +1,48 @@
+package kucoin
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCoinDepositAddressMemo(t *testing.T) {
+	tests := []struct {
+		context interface{}
+		want    string
+	}{
+		{nil, ""},
+		{"", ""},
+		{"123456", "123456"},
+		{float64(3503411547), "3503411547"},
+		{map[string]interface{}{"memo": "abc"}, "abc"},
+		{map[string]interface{}{"tag": float64(42)}, "42"},
+		{map[string]interface{}{"paymentId": "pid", "other": "x"}, "pid"},
+		{map[string]interface{}{"other": "x"}, ""},
+		{`{"memo":"from-json"}`, "from-json"},
+	}
+	for _, tt := range tests {
+		if got := (CoinDepositAddress{Context: tt.context}).Memo(); got != tt.want {
+			t.Errorf("Memo of %#v = %q, want %q", tt.context, got, tt.want)
+		}
+	}
+}
+
+func TestDepositAddressJSON(t *testing.T) {
+	a := CoinDepositAddress{CoinType: "KCS", Address: "0x1", CreatedAt: 1500000000000, UpdatedAt: 1500000000000}
+	data, err := json.Marshal(a.Typed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(data); strings.Contains(s, "lastReceivedAt") || strings.Contains(s, "memo") {
+		t.Errorf("empty fields are marshalled: %s", s)
+	}
+
+	a.LastReceivedAt = 1500000001000
+	if data, err = json.Marshal(a.Typed()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"lastReceivedAt"`) {
+		t.Errorf("lastReceivedAt is not marshalled: %s", data)
+	}
+}
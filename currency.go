@@ -0,0 +1,31 @@
+package kucoin
+
+import "strings"
+
+// ExchangeRates holds rates of coins in fiat currency.
+type ExchangeRates struct {
+	Currency string
+	// Rates maps coin to the price of one coin in Currency.
+	Rates map[string]float64
+}
+
+// Convert returns value of balance in fiat currency.
+// False is returned if there is no rate of balance coin.
+func (r ExchangeRates) Convert(balance CoinBalance) (float64, bool) {
+	rate, ok := r.Rates[strings.ToUpper(balance.CoinType)]
+	if !ok {
+		return 0, false
+	}
+	return balance.Total() * rate, true
+}
+
+type rawCurrencies struct {
+	Success   bool   `json:"success"`
+	Code      string `json:"code"`
+	Msg       string `json:"msg"`
+	Timestamp int64  `json:"timestamp"`
+	Data      struct {
+		Currencies [][]string                    `json:"currencies"`
+		Rates      map[string]map[string]float64 `json:"rates"`
+	} `json:"data"`
+}
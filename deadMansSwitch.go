@@ -0,0 +1,180 @@
+package kucoin
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DeadMansSwitch cancels all orders of tracked symbols when the application
+// stops sending heartbeats for longer than the configured timeout.
+type DeadMansSwitch struct {
+	cancel  func(symbol, side string) error
+	timeout time.Duration
+
+	mu      sync.Mutex
+	now     func() time.Time
+	last    time.Time
+	tripped bool
+	// symbols maps tracked symbol to the set of sides, "" means both sides.
+	symbols map[string]map[string]bool
+	// pending holds cancellations which have not succeeded since the switch tripped.
+	pending  map[string]map[string]bool
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDeadMansSwitch returns a DeadMansSwitch that cancels orders through
+// c, e.g. Kucoin client, if no heartbeat is received within timeout.
+func NewDeadMansSwitch(c OrdersCanceller, timeout time.Duration) *DeadMansSwitch {
+	return &DeadMansSwitch{
+		cancel:  c.CancelAllOrders,
+		timeout: timeout,
+		now:     time.Now,
+		last:    time.Now(),
+		symbols: make(map[string]map[string]bool),
+		stop:    make(chan struct{}),
+	}
+}
+
+// SetClock replaces the time source of the switch. It is intended for tests
+// which need to drive the switch with a fake clock.
+func (d *DeadMansSwitch) SetClock(now func() time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.now = now
+	d.last = now()
+}
+
+// Track adds symbol and side to the list of orders which are cancelled when
+// the switch trips. Side may be empty to cancel both BUY and SELL orders.
+// Tracking the same symbol with different sides cancels all of them.
+func (d *DeadMansSwitch) Track(symbol, side string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	symbol = normalizeSymbol(symbol)
+	if d.symbols[symbol] == nil {
+		d.symbols[symbol] = make(map[string]bool)
+	}
+	d.symbols[symbol][strings.ToUpper(side)] = true
+}
+
+// Untrack removes symbol with all its sides from the list of tracked symbols.
+func (d *DeadMansSwitch) Untrack(symbol string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.symbols, normalizeSymbol(symbol))
+}
+
+// Heartbeat signals that the application is alive and re-arms the switch
+// if it has already tripped. Cancellations which are still pending are dropped.
+func (d *DeadMansSwitch) Heartbeat() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.last = d.now()
+	if d.tripped {
+		log.Println("dead man's switch: heartbeat received, switch re-armed")
+		d.tripped = false
+		d.pending = nil
+	}
+}
+
+// Tripped reports whether the switch has fired since the last heartbeat.
+func (d *DeadMansSwitch) Tripped() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.tripped
+}
+
+// Pending returns number of cancellations which failed and will be retried.
+func (d *DeadMansSwitch) Pending() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := 0
+	for _, sides := range d.pending {
+		n += len(sides)
+	}
+	return n
+}
+
+// Check compares the time since the last heartbeat with the timeout and
+// cancels the orders of all tracked symbols once it is exceeded.
+// Failed cancellations are retried on the following calls until they succeed
+// or the next heartbeat is received.
+// It returns true if any cancellation was sent during this call.
+func (d *DeadMansSwitch) Check() bool {
+	d.mu.Lock()
+	elapsed := d.now().Sub(d.last)
+	if !d.tripped {
+		if elapsed < d.timeout {
+			d.mu.Unlock()
+			return false
+		}
+		d.tripped = true
+		d.pending = make(map[string]map[string]bool, len(d.symbols))
+		for symbol, sides := range d.symbols {
+			d.pending[symbol] = make(map[string]bool, len(sides))
+			for side := range sides {
+				d.pending[symbol][side] = true
+			}
+		}
+		log.Printf("dead man's switch: no heartbeat for %s, cancelling orders\n", elapsed)
+	}
+	type cancellation struct{ symbol, side string }
+	var todo []cancellation
+	for symbol, sides := range d.pending {
+		if sides[""] {
+			// Both sides are cancelled at once.
+			todo = append(todo, cancellation{symbol, ""})
+			continue
+		}
+		for side := range sides {
+			todo = append(todo, cancellation{symbol, side})
+		}
+	}
+	d.mu.Unlock()
+
+	for _, c := range todo {
+		err := d.cancel(c.symbol, c.side)
+		if err != nil {
+			log.Printf("dead man's switch: cancel %s %s err: %s, will retry\n", c.symbol, c.side, err)
+			continue
+		}
+		log.Printf("dead man's switch: cancel %s %s ok\n", c.symbol, c.side)
+		d.mu.Lock()
+		if sides, ok := d.pending[c.symbol]; ok {
+			if c.side == "" {
+				delete(d.pending, c.symbol)
+			} else if delete(sides, c.side); len(sides) == 0 {
+				delete(d.pending, c.symbol)
+			}
+		}
+		d.mu.Unlock()
+	}
+	return len(todo) > 0
+}
+
+// Start runs Check periodically in a separate goroutine until Stop is called.
+// Interval should be noticeably smaller than the timeout.
+func (d *DeadMansSwitch) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.Check()
+			case <-d.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop terminates the goroutine started by Start.
+func (d *DeadMansSwitch) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+	})
+}
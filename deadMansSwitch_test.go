@@ -0,0 +1,79 @@
+package kucoin
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeCanceller fails the first fail cancellations.
+// Trader is embedded only to satisfy the interface, its other methods are not used.
+type fakeCanceller struct {
+	Trader
+	fail  int
+	calls []string
+}
+
+func (f *fakeCanceller) CancelAllOrders(symbol, side string) error {
+	f.calls = append(f.calls, symbol+" "+side)
+	if f.fail > 0 {
+		f.fail--
+		return errors.New("network error")
+	}
+	return nil
+}
+
+func TestDeadMansSwitch(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := &fakeCanceller{fail: 1}
+	d := NewDeadMansSwitch(c, time.Minute)
+	d.SetClock(func() time.Time { return now })
+	d.Track("kcs-btc", "")
+
+	if d.Check() {
+		t.Fatal("switch tripped before timeout")
+	}
+	now = now.Add(time.Minute)
+	if !d.Check() || !d.Tripped() {
+		t.Fatal("switch did not trip after timeout")
+	}
+	if d.Pending() != 1 {
+		t.Fatalf("Pending = %d, want failed cancellation to be retried", d.Pending())
+	}
+	if !d.Check() || d.Pending() != 0 {
+		t.Fatalf("Pending = %d after retry, want 0", d.Pending())
+	}
+	if d.Check() {
+		t.Error("cancellation is sent again after it succeeded")
+	}
+	if len(c.calls) != 2 || c.calls[0] != "KCS-BTC " {
+		t.Errorf("calls = %q", c.calls)
+	}
+
+	d.Heartbeat()
+	if d.Tripped() {
+		t.Error("switch is not re-armed by heartbeat")
+	}
+}
+
+func TestDeadMansSwitchSides(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := &fakeCanceller{}
+	d := NewDeadMansSwitch(c, time.Minute)
+	d.SetClock(func() time.Time { return now })
+	d.Track("KCS-BTC", "buy")
+	d.Track("KCS-BTC", "sell")
+	d.Track("ETH-BTC", "sell")
+	d.Untrack("ETH-BTC")
+
+	now = now.Add(time.Minute)
+	d.Check()
+	if len(c.calls) != 2 {
+		t.Fatalf("calls = %q, want BUY and SELL of KCS-BTC", c.calls)
+	}
+	for _, call := range c.calls {
+		if call != "KCS-BTC BUY" && call != "KCS-BTC SELL" {
+			t.Errorf("unexpected call %q", call)
+		}
+	}
+}
@@ -32,23 +32,26 @@ type rawSpecificDealtOrder struct {
 
 // MergedDealtOrder struct represents kucoin data model.
 type MergedDealtOrder struct {
-	Total int `json:"total"`
-	Datas []struct {
-		CreatedAt     int64   `json:"createdAt"`
-		Amount        float64 `json:"amount"`
-		DealValue     float64 `json:"dealValue"`
-		DealPrice     float64 `json:"dealPrice"`
-		Fee           float64 `json:"fee"`
-		FeeRate       float64 `json:"feeRate"`
-		Oid           string  `json:"oid"`
-		OrderOid      string  `json:"orderOid"`
-		CoinType      string  `json:"coinType"`
-		CoinTypePair  string  `json:"coinTypePair"`
-		Direction     string  `json:"direction"`
-		DealDirection string  `json:"dealDirection"`
-	} `json:"datas"`
-	Limit int `json:"limit"`
-	Page  int `json:"page"`
+	Total int          `json:"total"`
+	Datas []DealtOrder `json:"datas"`
+	Limit int          `json:"limit"`
+	Page  int          `json:"page"`
+}
+
+// DealtOrder struct represents kucoin data model of single deal in MergedDealtOrder.
+type DealtOrder struct {
+	CreatedAt     int64   `json:"createdAt"`
+	Amount        float64 `json:"amount"`
+	DealValue     float64 `json:"dealValue"`
+	DealPrice     float64 `json:"dealPrice"`
+	Fee           float64 `json:"fee"`
+	FeeRate       float64 `json:"feeRate"`
+	Oid           string  `json:"oid"`
+	OrderOid      string  `json:"orderOid"`
+	CoinType      string  `json:"coinType"`
+	CoinTypePair  string  `json:"coinTypePair"`
+	Direction     string  `json:"direction"`
+	DealDirection string  `json:"dealDirection"`
 }
 
 type rawMergedDealtOrder struct {
@@ -0,0 +1,98 @@
+package kucoin
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AddressRotation describes deposit address changed by exchange.
+type AddressRotation struct {
+	Coin     string
+	Previous DepositAddress
+	Current  DepositAddress
+}
+
+// DepositAddressBook fetches and caches deposit addresses of multiple coins.
+type DepositAddressBook struct {
+	k *Kucoin
+
+	mu        sync.RWMutex
+	addresses map[string]DepositAddress
+}
+
+// NewDepositAddressBook returns empty DepositAddressBook.
+func NewDepositAddressBook(k *Kucoin) *DepositAddressBook {
+	return &DepositAddressBook{k: k, addresses: make(map[string]DepositAddress)}
+}
+
+// Fetch loads deposit addresses of coins and updates the book.
+// Addresses which were changed by exchange since the previous fetch are returned as rotations.
+func (b *DepositAddressBook) Fetch(coins ...string) (rotations []AddressRotation, err error) {
+	for _, coin := range coins {
+		coin = strings.ToUpper(coin)
+		var raw CoinDepositAddress
+		if raw, err = b.k.GetCoinDepositAddress(coin); err != nil {
+			return
+		}
+		current := raw.Typed()
+		if len(current.Coin) == 0 {
+			current.Coin = coin
+		}
+
+		b.mu.Lock()
+		previous, ok := b.addresses[coin]
+		b.addresses[coin] = current
+		b.mu.Unlock()
+
+		if ok && (!previous.UpdatedAt.Equal(current.UpdatedAt) ||
+			previous.Address != current.Address || previous.Memo != current.Memo) {
+			rotations = append(rotations, AddressRotation{Coin: coin, Previous: previous, Current: current})
+		}
+	}
+	return
+}
+
+// Get returns cached deposit address of coin.
+func (b *DepositAddressBook) Get(coin string) (DepositAddress, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	a, ok := b.addresses[strings.ToUpper(coin)]
+	return a, ok
+}
+
+// All returns cached deposit addresses sorted by coin.
+func (b *DepositAddressBook) All() []DepositAddress {
+	b.mu.RLock()
+	addresses := make([]DepositAddress, 0, len(b.addresses))
+	for _, a := range b.addresses {
+		addresses = append(addresses, a)
+	}
+	b.mu.RUnlock()
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Coin < addresses[j].Coin
+	})
+	return addresses
+}
+
+// WriteCSV exports address book as CSV with header
+// coin,address,memo,created_at,updated_at.
+func (b *DepositAddressBook) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"coin", "address", "memo", "created_at", "updated_at"}); err != nil {
+		return err
+	}
+	for _, a := range b.All() {
+		if err := cw.Write([]string{
+			a.Coin, a.Address, a.Memo,
+			a.CreatedAt.UTC().Format(time.RFC3339), a.UpdatedAt.UTC().Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,185 @@
+package kucoin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DepositEventType is the kind of DepositEvent.
+type DepositEventType int
+
+const (
+	// DepositAppeared is emitted when a new deposit record is found.
+	DepositAppeared DepositEventType = iota
+	// DepositFinished is emitted when deposit status changes to FINISHED.
+	DepositFinished
+	// DepositCancelled is emitted when deposit status changes to CANCEL.
+	DepositCancelled
+	// DepositConfirmed is emitted when pending deposit reaches
+	// the number of confirmations required by Kucoin.
+	DepositConfirmed
+)
+
+// DepositEvent describes change of deposit record.
+type DepositEvent struct {
+	Type           DepositEventType
+	Coin           string
+	Record         AccountRecord
+	PreviousStatus string
+	// Confirmations is the number of network confirmations reported by the record,
+	// -1 if the record doesn't report it.
+	Confirmations int
+	// RequiredConfirmations is the number of network confirmations
+	// required by Kucoin to finish deposit of the coin, i.e. Coin.ConfirmationCount.
+	RequiredConfirmations int
+}
+
+// Confirmed reports whether deposit is finished or has enough confirmations.
+func (e DepositEvent) Confirmed() bool {
+	if e.Record.Status == StatusFinished {
+		return true
+	}
+	return e.Confirmations >= 0 && e.Confirmations >= e.RequiredConfirmations
+}
+
+// depositState is the last known state of deposit record.
+type depositState struct {
+	status    string
+	confirmed bool
+}
+
+// DepositWatcher periodically scans deposit records of configured coins
+// and reports new deposits and their status changes.
+// Records are de-duplicated by Oid.
+type DepositWatcher struct {
+	k     *Kucoin
+	coins []string
+	// Limit is the number of the most recent records of each status scanned per coin.
+	Limit int
+	// ReportExisting enables events for deposits which already exist at the first scan.
+	ReportExisting bool
+
+	mu     sync.Mutex
+	seen   map[string]depositState
+	primed map[string]bool
+}
+
+// NewDepositWatcher returns DepositWatcher of given coins.
+func NewDepositWatcher(k *Kucoin, coins ...string) *DepositWatcher {
+	w := &DepositWatcher{
+		k:      k,
+		Limit:  100,
+		seen:   make(map[string]depositState),
+		primed: make(map[string]bool),
+	}
+	for _, c := range coins {
+		w.coins = append(w.coins, strings.ToUpper(c))
+	}
+	return w
+}
+
+// Scan fetches deposit records of all coins once and returns events
+// since the previous scan. Coin which fails to scan is skipped and retried
+// on the next scan, events of other coins are returned together with the first error.
+func (w *DepositWatcher) Scan() (events []DepositEvent, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, coin := range w.coins {
+		coinEvents, updates, coinErr := w.scanCoin(coin)
+		if coinErr != nil {
+			if err == nil {
+				err = fmt.Errorf("scan %s deposits: %w", coin, coinErr)
+			}
+			continue
+		}
+		for oid, state := range updates {
+			w.seen[oid] = state
+		}
+		if w.primed[coin] || w.ReportExisting {
+			events = append(events, coinEvents...)
+		}
+		w.primed[coin] = true
+	}
+	return
+}
+
+// scanCoin returns events of coin and state updates which must be applied
+// to seen records. Seen records are not modified.
+func (w *DepositWatcher) scanCoin(coin string) (events []DepositEvent, updates map[string]depositState, err error) {
+	c, err := w.k.coinInfo(coin)
+	if err != nil {
+		return
+	}
+	updates = make(map[string]depositState)
+	for _, status := range []string{StatusPending, StatusFinished, StatusCancel} {
+		var history AccountHistory
+		if history, err = w.k.AccountHistory(coin, "DEPOSIT", status, w.Limit, 1); err != nil {
+			return
+		}
+		for _, r := range history.Datas {
+			previous, seen := updates[r.Oid]
+			if !seen {
+				previous, seen = w.seen[r.Oid]
+			}
+			event := DepositEvent{
+				Coin:                  coin,
+				Record:                r,
+				PreviousStatus:        previous.status,
+				Confirmations:         -1,
+				RequiredConfirmations: c.ConfirmationCount,
+			}
+			if n, ok := r.Confirmations(); ok {
+				event.Confirmations = n
+			}
+			state := depositState{status: r.Status, confirmed: previous.confirmed || event.Confirmed()}
+			if seen && previous == state {
+				continue
+			}
+			updates[r.Oid] = state
+			if !seen {
+				event.Type = DepositAppeared
+				events = append(events, event)
+				// Deposit which is already final at first sight gets both events.
+				event.PreviousStatus = StatusPending
+			}
+			switch r.Status {
+			case StatusFinished:
+				event.Type = DepositFinished
+				events = append(events, event)
+			case StatusCancel:
+				event.Type = DepositCancelled
+				events = append(events, event)
+			default:
+				if state.confirmed && !previous.confirmed {
+					event.Type = DepositConfirmed
+					events = append(events, event)
+				}
+			}
+		}
+	}
+	return
+}
+
+// Run calls Scan with interval and passes events to handler until ctx is cancelled.
+// Scan errors are passed to onErr, if it is not nil, and watching continues.
+func (w *DepositWatcher) Run(ctx context.Context, interval time.Duration, handler func(DepositEvent), onErr func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		events, err := w.Scan()
+		if err != nil && onErr != nil {
+			onErr(err)
+		}
+		for _, e := range events {
+			handler(e)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
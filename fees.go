@@ -0,0 +1,128 @@
+package kucoin
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// FeeReportRow aggregates fees paid for single symbol in single period.
+// Volume and Fees are expressed in quote currency of the symbol.
+type FeeReportRow struct {
+	Symbol string
+	// Period is the start of aggregation period, zero if report is not split by periods.
+	Period        time.Time
+	Deals         int
+	Volume        float64
+	Fees          float64
+	EffectiveRate float64
+	// RateDiff is EffectiveRate minus base fee rate of the user.
+	RateDiff float64
+}
+
+// FeeReport aggregates paid fees per symbol and period.
+type FeeReport struct {
+	BaseFeeRate float64
+	Rows        []FeeReportRow
+}
+
+// NewFeeReport builds fee report from dealt orders. Zero period aggregates
+// all deals of a symbol into single row. BaseFeeRate is usually UserInfo.BaseFeeRate.
+func NewFeeReport(orders []DealtOrder, period time.Duration, baseFeeRate float64) FeeReport {
+	type key struct {
+		symbol string
+		period time.Time
+	}
+	rows := map[key]*FeeReportRow{}
+	for _, o := range orders {
+		d := o.Deal()
+		k := key{symbol: d.Symbol}
+		if period > 0 {
+			k.period = d.Time.UTC().Truncate(period)
+		}
+		row, ok := rows[k]
+		if !ok {
+			row = &FeeReportRow{Symbol: k.symbol, Period: k.period}
+			rows[k] = row
+		}
+		row.Deals++
+		row.Volume += o.DealValue
+		row.Fees += d.FeeQuote()
+	}
+
+	report := FeeReport{BaseFeeRate: baseFeeRate}
+	for _, row := range rows {
+		if row.Volume > 0 {
+			row.EffectiveRate = row.Fees / row.Volume
+		}
+		row.RateDiff = row.EffectiveRate - baseFeeRate
+		report.Rows = append(report.Rows, *row)
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		if report.Rows[i].Symbol == report.Rows[j].Symbol {
+			return report.Rows[i].Period.Before(report.Rows[j].Period)
+		}
+		return report.Rows[i].Symbol < report.Rows[j].Symbol
+	})
+	return report
+}
+
+// FeesBySymbol returns total fees of each symbol in its quote currency.
+func (r FeeReport) FeesBySymbol() map[string]float64 {
+	fees := map[string]float64{}
+	for _, row := range r.Rows {
+		fees[row.Symbol] += row.Fees
+	}
+	return fees
+}
+
+// FeeEstimate is the projected fee of prospective order.
+type FeeEstimate struct {
+	Symbol  string
+	Side    string
+	FeeRate float64
+	// Fee is charged in FeeAsset: base coin for BUY and quote coin for SELL.
+	Fee      float64
+	FeeAsset string
+	// FeeQuote is the fee expressed in quote currency.
+	FeeQuote float64
+}
+
+// EstimateOrderFee is used to project the fee of order before it is sent with CreateOrder.
+// Fee rate of the symbol is taken from market cache set with SetRounding, or from Kucoin.
+// Base fee rate of the user is used if symbol has no fee rate.
+func (b *Kucoin) EstimateOrderFee(symbol, side string, price, amount float64) (estimate FeeEstimate, err error) {
+	var s Symbol
+	if b.market != nil {
+		s, err = b.market.Symbol(symbol)
+	} else {
+		s, err = b.GetSymbol(symbol)
+	}
+	if err != nil {
+		return
+	}
+	rate := s.FeeRate
+	if rate == 0 {
+		var userInfo UserInfo
+		if userInfo, err = b.GetUserInfo(); err != nil {
+			return
+		}
+		rate = userInfo.BaseFeeRate
+	}
+	return estimateOrderFee(s, side, price, amount, rate), nil
+}
+
+func estimateOrderFee(s Symbol, side string, price, amount, rate float64) FeeEstimate {
+	e := FeeEstimate{
+		Symbol:   normalizeSymbol(s.Symbol),
+		Side:     strings.ToUpper(side),
+		FeeRate:  rate,
+		FeeQuote: price * amount * rate,
+	}
+	if e.Side == "BUY" {
+		e.Fee, e.FeeAsset = amount*rate, strings.ToUpper(s.CoinType)
+	} else {
+		e.Fee, e.FeeAsset = e.FeeQuote, strings.ToUpper(s.CoinTypePair)
+	}
+	return e
+}
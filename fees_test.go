@@ -0,0 +1,93 @@
+package kucoin
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewFeeReport(t *testing.T) {
+	at := func(hour, min int) int64 {
+		return timeToMs(time.Date(2018, 1, 1, hour, min, 0, 0, time.UTC))
+	}
+	orders := []DealtOrder{
+		// 0.1 KCS fee of BUY is 0.00002 BTC at deal price.
+		{CreatedAt: at(10, 10), CoinType: "KCS", CoinTypePair: "BTC", Direction: "BUY",
+			DealPrice: 0.0002, Amount: 100, DealValue: 0.02, Fee: 0.1},
+		{CreatedAt: at(10, 50), CoinType: "KCS", CoinTypePair: "BTC", Direction: "SELL",
+			DealPrice: 0.0002, Amount: 50, DealValue: 0.01, Fee: 0.00001},
+		{CreatedAt: at(11, 5), CoinType: "KCS", CoinTypePair: "BTC", Direction: "SELL",
+			DealPrice: 0.0002, Amount: 50, DealValue: 0.01, Fee: 0.000005},
+		{CreatedAt: at(10, 20), CoinType: "ETH", CoinTypePair: "BTC", Direction: "BUY",
+			DealPrice: 0.05, Amount: 1, DealValue: 0.05, Fee: 0.001},
+	}
+	hour := func(h int) time.Time {
+		return time.Date(2018, 1, 1, h, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name   string
+		period time.Duration
+		want   []FeeReportRow
+	}{
+		{"all time", 0, []FeeReportRow{
+			{Symbol: "ETH-BTC", Deals: 1, Volume: 0.05, Fees: 0.00005, EffectiveRate: 0.001},
+			{Symbol: "KCS-BTC", Deals: 3, Volume: 0.04, Fees: 0.000035, EffectiveRate: 0.000875, RateDiff: -0.000125},
+		}},
+		{"hourly", time.Hour, []FeeReportRow{
+			{Symbol: "ETH-BTC", Period: hour(10), Deals: 1, Volume: 0.05, Fees: 0.00005, EffectiveRate: 0.001},
+			{Symbol: "KCS-BTC", Period: hour(10), Deals: 2, Volume: 0.03, Fees: 0.00003, EffectiveRate: 0.001},
+			{Symbol: "KCS-BTC", Period: hour(11), Deals: 1, Volume: 0.01, Fees: 0.000005, EffectiveRate: 0.0005, RateDiff: -0.0005},
+		}},
+	}
+	for _, test := range tests {
+		report := NewFeeReport(orders, test.period, 0.001)
+		if len(report.Rows) != len(test.want) {
+			t.Errorf("%s: rows = %+v, want %+v", test.name, report.Rows, test.want)
+			continue
+		}
+		for i, want := range test.want {
+			got := report.Rows[i]
+			if got.Symbol != want.Symbol || !got.Period.Equal(want.Period) || got.Deals != want.Deals ||
+				!almostEqual(got.Volume, want.Volume) || !almostEqual(got.Fees, want.Fees) ||
+				!almostEqual(got.EffectiveRate, want.EffectiveRate) || !almostEqual(got.RateDiff, want.RateDiff) {
+				t.Errorf("%s: row %d = %+v, want %+v", test.name, i, got, want)
+			}
+		}
+	}
+}
+
+func TestEstimateOrderFee(t *testing.T) {
+	kcs := Symbol{Symbol: "kcs-btc", CoinType: "KCS", CoinTypePair: "BTC"}
+	tests := []struct {
+		side     string
+		fee      float64
+		feeAsset string
+		feeQuote float64
+	}{
+		{"buy", 0.1, "KCS", 0.00002},
+		{"SELL", 0.00002, "BTC", 0.00002},
+	}
+	for _, test := range tests {
+		e := estimateOrderFee(kcs, test.side, 0.0002, 100, 0.001)
+		if e.Symbol != "KCS-BTC" || e.Side != strings.ToUpper(test.side) || e.FeeAsset != test.feeAsset ||
+			!almostEqual(e.Fee, test.fee) || !almostEqual(e.FeeQuote, test.feeQuote) {
+			t.Errorf("%s estimate = %+v, want %v %s, %v in quote", test.side, e, test.fee, test.feeAsset, test.feeQuote)
+		}
+	}
+
+	// Symbol without fee rate falls back to base fee rate of the user.
+	k := newTestKucoin(func(r *http.Request) string {
+		if strings.HasSuffix(r.URL.Path, "user/info") {
+			return `{"success":true,"code":"OK","data":{"baseFeeRate":0.002}}`
+		}
+		return `{"success":true,"code":"OK","data":{"symbol":"KCS-BTC","coinType":"KCS","coinTypePair":"BTC","feeRate":0}}`
+	})
+	e, err := k.EstimateOrderFee("KCS-BTC", "BUY", 0.0002, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.FeeRate != 0.002 || !almostEqual(e.Fee, 0.2) || e.FeeAsset != "KCS" {
+		t.Errorf("estimate = %+v, want 0.2 KCS at base fee rate 0.002", e)
+	}
+}
@@ -0,0 +1,52 @@
+package indicators
+
+import "math"
+
+// Bollinger is Bollinger bands indicator. Its Value is the middle band.
+type Bollinger struct {
+	w window
+	k float64
+}
+
+// NewBollinger returns Bollinger bands of period values with bands k
+// standard deviations away from the middle, usually 20 and 2.
+func NewBollinger(period int, k float64) *Bollinger {
+	return &Bollinger{w: newWindow(period), k: k}
+}
+
+// Update implements Indicator.
+func (b *Bollinger) Update(value float64) float64 {
+	b.w.push(value)
+	return b.Value()
+}
+
+// Value implements Indicator.
+func (b *Bollinger) Value() float64 {
+	return b.w.mean()
+}
+
+// StdDev returns population standard deviation of the window.
+func (b *Bollinger) StdDev() float64 {
+	return math.Sqrt(b.w.variance())
+}
+
+// Bands returns lower, middle and upper bands.
+func (b *Bollinger) Bands() (lower, middle, upper float64) {
+	middle = b.Value()
+	d := b.k * b.StdDev()
+	return middle - d, middle, middle + d
+}
+
+// PercentB returns position of value relative to bands, 0 at lower and 1 at upper band.
+func (b *Bollinger) PercentB(value float64) float64 {
+	lower, _, upper := b.Bands()
+	if upper == lower {
+		return 0.5
+	}
+	return (value - lower) / (upper - lower)
+}
+
+// Ready implements Indicator.
+func (b *Bollinger) Ready() bool {
+	return b.w.full()
+}
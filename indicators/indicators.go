@@ -0,0 +1,93 @@
+// Package indicators implements streaming technical indicators.
+// Each indicator is updated with one value at a time in constant time
+// and doesn't allocate after construction, so thousands of them may be
+// kept for hundreds of symbols.
+package indicators
+
+import "github.com/eeonevision/kucoin-go"
+
+// Indicator is a streaming single-value indicator.
+type Indicator interface {
+	// Update adds value and returns the current indicator value.
+	Update(value float64) float64
+	// Value returns the current indicator value.
+	Value() float64
+	// Ready reports whether enough values were added for meaningful result.
+	Ready() bool
+}
+
+// UpdateCandle updates indicator with close price of candle.
+func UpdateCandle(ind Indicator, c kucoin.Candle) float64 {
+	return ind.Update(c.Close)
+}
+
+// UpdateCandles updates indicator with close prices of candles in order.
+func UpdateCandles(ind Indicator, candles []kucoin.Candle) float64 {
+	for _, c := range candles {
+		ind.Update(c.Close)
+	}
+	return ind.Value()
+}
+
+// UpdateTicker updates indicator with last deal price of ticker.
+func UpdateTicker(ind Indicator, s kucoin.Symbol) float64 {
+	return ind.Update(s.LastDealPrice)
+}
+
+// window is a fixed-size ring buffer of values with running sums.
+type window struct {
+	values []float64
+	next   int
+	count  int
+	sum    float64
+}
+
+func newWindow(size int) window {
+	if size < 1 {
+		size = 1
+	}
+	return window{values: make([]float64, size)}
+}
+
+func (w *window) push(v float64) {
+	if w.count == len(w.values) {
+		w.sum -= w.values[w.next]
+	} else {
+		w.count++
+	}
+	w.values[w.next] = v
+	w.sum += v
+	w.next = (w.next + 1) % len(w.values)
+	if w.next == 0 {
+		// Running sum is recomputed once per window to drop accumulated rounding errors.
+		w.sum = 0
+		for _, v := range w.values[:w.count] {
+			w.sum += v
+		}
+	}
+}
+
+func (w *window) full() bool {
+	return w.count == len(w.values)
+}
+
+func (w *window) mean() float64 {
+	if w.count == 0 {
+		return 0
+	}
+	return w.sum / float64(w.count)
+}
+
+// variance returns population variance of the window. It is computed from
+// the stored values, as running sum of squares loses precision on large values.
+func (w *window) variance() float64 {
+	if w.count == 0 {
+		return 0
+	}
+	mean := w.mean()
+	var sumSq float64
+	for _, v := range w.values[:w.count] {
+		sumSq += (v - mean) * (v - mean)
+	}
+	return sumSq / float64(w.count)
+}
@@ -0,0 +1,47 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSMA(t *testing.T) {
+	s := NewSMA(3)
+	for i, want := range []float64{1, 1.5, 2, 3, 4} {
+		if got := s.Update(float64(i + 1)); got != want {
+			t.Errorf("SMA after %d values = %v, want %v", i+1, got, want)
+		}
+	}
+	if !s.Ready() {
+		t.Error("SMA is not ready after period values")
+	}
+}
+
+func TestBollingerStdDev(t *testing.T) {
+	b := NewBollinger(4, 2)
+	for _, v := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		b.Update(v)
+	}
+	// Last 4 values are 5, 5, 7, 9: mean 6.5, variance 2.75.
+	if got, want := b.StdDev(), math.Sqrt(2.75); math.Abs(got-want) > 1e-12 {
+		t.Errorf("StdDev = %v, want %v", got, want)
+	}
+	lower, middle, upper := b.Bands()
+	if middle != 6.5 || math.Abs(upper-middle-2*math.Sqrt(2.75)) > 1e-12 || math.Abs(middle-lower-2*math.Sqrt(2.75)) > 1e-12 {
+		t.Errorf("Bands = %v, %v, %v", lower, middle, upper)
+	}
+}
+
+func TestBollingerNoDrift(t *testing.T) {
+	// Large prices with small moves lose precision in running sums of squares.
+	b := NewBollinger(20, 2)
+	for i := 0; i < 100000; i++ {
+		b.Update(1e8 + float64(i%2)*0.01)
+	}
+	if got := b.StdDev(); math.Abs(got-0.005) > 1e-6 {
+		t.Errorf("StdDev = %v, want 0.005", got)
+	}
+	if got := b.Value(); math.Abs(got-(1e8+0.005)) > 1e-6 {
+		t.Errorf("Value = %v, want %v", got, 1e8+0.005)
+	}
+}
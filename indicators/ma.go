@@ -0,0 +1,65 @@
+package indicators
+
+// SMA is simple moving average.
+type SMA struct {
+	w window
+}
+
+// NewSMA returns SMA of period values.
+func NewSMA(period int) *SMA {
+	return &SMA{w: newWindow(period)}
+}
+
+// Update implements Indicator.
+func (s *SMA) Update(value float64) float64 {
+	s.w.push(value)
+	return s.Value()
+}
+
+// Value implements Indicator.
+func (s *SMA) Value() float64 {
+	return s.w.mean()
+}
+
+// Ready implements Indicator.
+func (s *SMA) Ready() bool {
+	return s.w.full()
+}
+
+// EMA is exponential moving average with smoothing factor 2 / (period + 1).
+// It is seeded with simple average of the first period values.
+type EMA struct {
+	period int
+	alpha  float64
+	count  int
+	value  float64
+}
+
+// NewEMA returns EMA of period values.
+func NewEMA(period int) *EMA {
+	if period < 1 {
+		period = 1
+	}
+	return &EMA{period: period, alpha: 2 / float64(period+1)}
+}
+
+// Update implements Indicator.
+func (e *EMA) Update(value float64) float64 {
+	if e.count < e.period {
+		e.count++
+		e.value += (value - e.value) / float64(e.count)
+		return e.value
+	}
+	e.value += e.alpha * (value - e.value)
+	return e.value
+}
+
+// Value implements Indicator.
+func (e *EMA) Value() float64 {
+	return e.value
+}
+
+// Ready implements Indicator.
+func (e *EMA) Ready() bool {
+	return e.count >= e.period
+}
@@ -0,0 +1,44 @@
+package indicators
+
+// MACD is moving average convergence divergence.
+// Its Value is the MACD line, i.e. fast EMA minus slow EMA.
+type MACD struct {
+	fast   EMA
+	slow   EMA
+	signal EMA
+}
+
+// NewMACD returns MACD with given periods, usually 12, 26 and 9.
+func NewMACD(fast, slow, signal int) *MACD {
+	return &MACD{fast: *NewEMA(fast), slow: *NewEMA(slow), signal: *NewEMA(signal)}
+}
+
+// Update implements Indicator.
+func (m *MACD) Update(value float64) float64 {
+	m.fast.Update(value)
+	m.slow.Update(value)
+	if m.slow.Ready() {
+		m.signal.Update(m.Value())
+	}
+	return m.Value()
+}
+
+// Value implements Indicator.
+func (m *MACD) Value() float64 {
+	return m.fast.Value() - m.slow.Value()
+}
+
+// Signal returns signal line, i.e. EMA of MACD line.
+func (m *MACD) Signal() float64 {
+	return m.signal.Value()
+}
+
+// Histogram returns MACD line minus signal line.
+func (m *MACD) Histogram() float64 {
+	return m.Value() - m.Signal()
+}
+
+// Ready implements Indicator.
+func (m *MACD) Ready() bool {
+	return m.slow.Ready() && m.signal.Ready()
+}
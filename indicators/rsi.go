@@ -0,0 +1,62 @@
+package indicators
+
+// RSI is relative strength index with Wilder's smoothing.
+type RSI struct {
+	period  int
+	count   int
+	prev    float64
+	avgGain float64
+	avgLoss float64
+}
+
+// NewRSI returns RSI of period values, usually 14.
+func NewRSI(period int) *RSI {
+	if period < 1 {
+		period = 1
+	}
+	return &RSI{period: period}
+}
+
+// Update implements Indicator.
+func (r *RSI) Update(value float64) float64 {
+	if r.count == 0 {
+		r.prev = value
+		r.count++
+		return r.Value()
+	}
+	change := value - r.prev
+	r.prev = value
+	var gain, loss float64
+	if change > 0 {
+		gain = change
+	} else {
+		loss = -change
+	}
+	if r.count <= r.period {
+		// Simple average of the first period changes.
+		r.avgGain += (gain - r.avgGain) / float64(r.count)
+		r.avgLoss += (loss - r.avgLoss) / float64(r.count)
+	} else {
+		n := float64(r.period)
+		r.avgGain = (r.avgGain*(n-1) + gain) / n
+		r.avgLoss = (r.avgLoss*(n-1) + loss) / n
+	}
+	r.count++
+	return r.Value()
+}
+
+// Value implements Indicator. It returns 50 until the first change is known.
+func (r *RSI) Value() float64 {
+	if r.avgGain == 0 && r.avgLoss == 0 {
+		return 50
+	}
+	if r.avgLoss == 0 {
+		return 100
+	}
+	return 100 - 100/(1+r.avgGain/r.avgLoss)
+}
+
+// Ready implements Indicator.
+func (r *RSI) Ready() bool {
+	return r.count > r.period
+}
@@ -2,6 +2,7 @@
 package kucoin
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,21 +19,21 @@ const (
 // New returns an instantiated Kucoin struct.
 func New(apiKey, apiSecret string) *Kucoin {
 	client := newClient(apiKey, apiSecret)
-	return &Kucoin{client}
+	return &Kucoin{client: client}
 }
 
 // NewCustomClient returns an instantiated Kucoin struct with custom http client.
 func NewCustomClient(apiKey, apiSecret string, httpClient http.Client) *Kucoin {
 	client := newClient(apiKey, apiSecret)
 	client.httpClient = httpClient
-	return &Kucoin{client}
+	return &Kucoin{client: client}
 }
 
 // NewCustomTimeout returns an instantiated Kucoin struct with custom timeout.
 func NewCustomTimeout(apiKey, apiSecret string, timeout time.Duration) *Kucoin {
 	client := newClient(apiKey, apiSecret)
 	client.httpClient.Timeout = timeout
-	return &Kucoin{client}
+	return &Kucoin{client: client}
 }
 
 func doArgs(args ...string) map[string]string {
@@ -72,8 +73,15 @@ func handleErr(r interface{}) error {
 }
 
 // Kucoin represent a Kucoin client.
+// Methods which take symbol accept it in any case and with "-", "_" or "/"
+// separator and send it to Kucoin in uniform KCS-BTC format, see Pair.
 type Kucoin struct {
-	client *client
+	client            *client
+	market            *MarketInfo
+	roundMode         RoundMode
+	symbols           *MarketInfo
+	allowList         *AddressAllowList
+	addressValidators map[string]AddressValidator
 }
 
 // SetDebug enables/disables http request/response dump.
@@ -81,6 +89,22 @@ func (b *Kucoin) SetDebug(enable bool) {
 	b.client.debug = enable
 }
 
+// SetRounding enables automatic rounding of price and amount in CreateOrder
+// according to precision rules provided by market. Nil market disables rounding
+// and CreateOrder sends values with 8 decimals.
+func (b *Kucoin) SetRounding(market *MarketInfo, mode RoundMode) {
+	b.market = market
+	b.roundMode = mode
+}
+
+// SetMarketInfo enables validation of symbols against the symbols listed in market
+// before requests are sent. Symbol missing in the cache triggers one refresh of market,
+// so newly listed symbols are accepted. History and cancel methods are not validated,
+// so orders of delisted symbols can still be read and cancelled. Nil market disables validation.
+func (b *Kucoin) SetMarketInfo(market *MarketInfo) {
+	b.symbols = market
+}
+
 // GetUserInfo is used to get the user information at Kucoin along with other meta data.
 func (b *Kucoin) GetUserInfo() (userInfo UserInfo, err error) {
 	r, err := b.client.do("GET", "user/info", nil, true)
@@ -129,7 +153,9 @@ func (b *Kucoin) GetUserSymbols(market, symbol, filter string) (symbols []Symbol
 		payload["market"] = market
 	}
 	if len(symbol) > 1 {
-		payload["symbol"] = symbol
+		if payload["symbol"], err = b.resolveSymbol(symbol); err != nil {
+			return
+		}
 	}
 	if len(filter) > 1 {
 		payload["filter"] = filter
@@ -151,11 +177,92 @@ func (b *Kucoin) GetUserSymbols(market, symbol, filter string) (symbols []Symbol
 	return
 }
 
+// GetMarkets is used to get the list of markets at Kucoin, e.g. BTC, ETH, USDT, KCS.
+func (b *Kucoin) GetMarkets() (markets []string, err error) {
+	r, err := b.client.do("GET", "open/markets", nil, false)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	var rawRes rawMarkets
+	err = json.Unmarshal(r, &rawRes)
+	markets = rawRes.Data
+	return
+}
+
+// FavouriteSymbols is used to get the symbols marked by user as favourite at Kucoin.
+func (b *Kucoin) FavouriteSymbols() ([]Symbol, error) {
+	return b.GetUserSymbols("", "", "FAVOURITE")
+}
+
+// StickSymbols is used to get the symbols stuck by user at Kucoin.
+func (b *Kucoin) StickSymbols() ([]Symbol, error) {
+	return b.GetUserSymbols("", "", "STICK")
+}
+
+// AddFavourite is used to mark symbol as favourite at Kucoin.
+func (b *Kucoin) AddFavourite(symbol string) error {
+	return b.setSymbolFlag("market/symbol/fav", "fav", symbol, true)
+}
+
+// RemoveFavourite is used to unmark favourite symbol at Kucoin.
+func (b *Kucoin) RemoveFavourite(symbol string) error {
+	return b.setSymbolFlag("market/symbol/fav", "fav", symbol, false)
+}
+
+// AddStick is used to stick symbol at Kucoin.
+func (b *Kucoin) AddStick(symbol string) error {
+	return b.setSymbolFlag("market/symbol/stick", "stick", symbol, true)
+}
+
+// RemoveStick is used to unstick symbol at Kucoin.
+func (b *Kucoin) RemoveStick(symbol string) error {
+	return b.setSymbolFlag("market/symbol/stick", "stick", symbol, false)
+}
+
+func (b *Kucoin) setSymbolFlag(resource, flag, symbol string, enable bool) error {
+	if len(symbol) < 1 {
+		return fmt.Errorf("The symbol is required")
+	}
+	payload := map[string]string{}
+	var err error
+	if payload["symbol"], err = b.resolveSymbol(symbol); err != nil {
+		return err
+	}
+	if enable {
+		payload[flag] = "1"
+	} else {
+		payload[flag] = "0"
+	}
+
+	r, err := b.client.do("POST", resource, payload, true)
+	if err != nil {
+		return err
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return err
+	}
+	return handleErr(response)
+}
+
 // GetSymbol is used to get the open and available trading market at Kucoin along with other meta data.
-// Trading symbol e.g. KCS-BTC. If not specified then you will get data of all symbols.
+// Trading symbol e.g. KCS-BTC. If not specified then you will get data of all symbols,
+// use GetTickers to get them decoded as a slice.
 func (b *Kucoin) GetSymbol(market string) (symbol Symbol, err error) {
+	if len(market) > 0 {
+		if market, err = b.resolveSymbol(market); err != nil {
+			return
+		}
+	}
 	r, err := b.client.do("GET",
-		"open/tick", doArgs("symbol", strings.ToUpper(market)), false,
+		"open/tick", doArgs("symbol", market), false,
 	)
 	if err != nil {
 		return
@@ -173,6 +280,25 @@ func (b *Kucoin) GetSymbol(market string) (symbol Symbol, err error) {
 	return
 }
 
+// GetTickers is used to get the tickers of all symbols at Kucoin at once.
+func (b *Kucoin) GetTickers() (symbols []Symbol, err error) {
+	r, err := b.client.do("GET", "open/tick", nil, false)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	var rawRes rawSymbols
+	err = json.Unmarshal(r, &rawRes)
+	symbols = rawRes.Data
+	return
+}
+
 // GetCoins is used to get the all open and available trading coins at Kucoin along with other meta data.
 func (b *Kucoin) GetCoins() (coins []Coin, err error) {
 	r, err := b.client.do("GET", "market/open/coins", nil, false)
@@ -213,6 +339,50 @@ func (b *Kucoin) GetCoin(c string) (coin Coin, err error) {
 	return
 }
 
+// GetExchangeRates is used to get the rates of coins in fiat currency at Kucoin.
+// Currency is required parameter, e.g. USD, usually UserInfo.Currency.
+// Coins are required, e.g. BTC, ETH. Error is returned if any of coins
+// has no rate in the currency.
+func (b *Kucoin) GetExchangeRates(currency string, coins ...string) (rates ExchangeRates, err error) {
+	if len(currency) < 1 || len(coins) < 1 {
+		return rates, fmt.Errorf("The not all required parameters are presented")
+	}
+	rates.Currency = strings.ToUpper(currency)
+	r, err := b.client.do("GET", "open/currencies",
+		doArgs("coins", strings.ToUpper(strings.Join(coins, ",")), "currency", rates.Currency), false,
+	)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	var rawRes rawCurrencies
+	if err = json.Unmarshal(r, &rawRes); err != nil {
+		return
+	}
+	rates.Rates = make(map[string]float64)
+	for coin, coinRates := range rawRes.Data.Rates {
+		if rate, ok := coinRates[rates.Currency]; ok {
+			rates.Rates[strings.ToUpper(coin)] = rate
+		}
+	}
+	var missing []string
+	for _, coin := range coins {
+		if _, ok := rates.Rates[strings.ToUpper(coin)]; !ok {
+			missing = append(missing, strings.ToUpper(coin))
+		}
+	}
+	if len(missing) > 0 {
+		err = fmt.Errorf("No %s rate of %s", rates.Currency, strings.Join(missing, ", "))
+	}
+	return
+}
+
 // GetCoinBalance is used to get the balance at chosen coin at Kucoin along with other meta data.
 func (b *Kucoin) GetCoinBalance(c string) (coinBalance CoinBalance, err error) {
 	r, err := b.client.do("GET", fmt.Sprintf("account/%s/balance", strings.ToUpper(c)), nil, true)
@@ -232,6 +402,59 @@ func (b *Kucoin) GetCoinBalance(c string) (coinBalance CoinBalance, err error) {
 	return
 }
 
+// ListCoinBalances is used to get the page of balances of all coins at Kucoin along with other meta data.
+// Limit may be zero, and not greater than 20. Page may be zero and by default is equal to 1.
+func (b *Kucoin) ListCoinBalances(ctx context.Context, limit, page int) (coinBalances CoinBalances, err error) {
+	payload := map[string]string{}
+	if limit == 0 || limit > 20 {
+		payload["limit"] = fmt.Sprintf("%v", 20)
+	} else {
+		payload["limit"] = fmt.Sprintf("%v", limit)
+	}
+	if page == 0 {
+		payload["page"] = fmt.Sprintf("%v", 1)
+	} else {
+		payload["page"] = fmt.Sprintf("%v", page)
+	}
+
+	r, err := b.client.doContext(ctx, "GET", "account/balances", payload, true)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	var rawRes rawCoinBalances
+	err = json.Unmarshal(r, &rawRes)
+	coinBalances = rawRes.Data
+	return
+}
+
+// GetBalances is used to get the balances of all coins at Kucoin keyed by coin.
+// All pages are fetched. If nonZero is true, coins with zero balance are omitted.
+func (b *Kucoin) GetBalances(ctx context.Context, nonZero bool) (balances map[string]CoinBalance, err error) {
+	balances = make(map[string]CoinBalance)
+	for page := 1; ; page++ {
+		var coinBalances CoinBalances
+		if coinBalances, err = b.ListCoinBalances(ctx, 20, page); err != nil {
+			return nil, err
+		}
+		for _, balance := range coinBalances.Datas {
+			if nonZero && balance.Balance == 0 && balance.FreezeBalance == 0 {
+				continue
+			}
+			balances[strings.ToUpper(balance.CoinType)] = balance
+		}
+		if len(coinBalances.Datas) == 0 || page >= coinBalances.PageNos {
+			return
+		}
+	}
+}
+
 // GetCoinDepositAddress is used to get the address at chosen coin at Kucoin along with other meta data.
 func (b *Kucoin) GetCoinDepositAddress(c string) (coinDepositAddress CoinDepositAddress, err error) {
 	r, err := b.client.do("GET", fmt.Sprintf("account/%s/wallet/address", strings.ToUpper(c)), nil, true)
@@ -259,7 +482,9 @@ func (b *Kucoin) ListActiveMapOrders(symbol string, side string) (activeMapOrder
 		return activeMapOrders, fmt.Errorf("Symbol is required")
 	}
 	payload := make(map[string]string)
-	payload["symbol"] = strings.ToUpper(symbol)
+	if payload["symbol"], err = parseSymbol(symbol); err != nil {
+		return
+	}
 	if len(side) > 1 {
 		payload["side"] = strings.ToUpper(side)
 	}
@@ -289,7 +514,9 @@ func (b *Kucoin) ListActiveOrders(symbol string, side string) (activeOrders Acti
 		return activeOrders, fmt.Errorf("The symbol is required")
 	}
 	payload := make(map[string]string)
-	payload["symbol"] = strings.ToUpper(symbol)
+	if payload["symbol"], err = parseSymbol(symbol); err != nil {
+		return
+	}
 	if len(side) > 1 {
 		payload["side"] = strings.ToUpper(side)
 	}
@@ -314,11 +541,18 @@ func (b *Kucoin) ListActiveOrders(symbol string, side string) (activeOrders Acti
 // OrdersBook is used to get the information about active orders at Kucoin along with other meta data.
 // Symbol is required parameter, geoup and limit may be empty.
 func (b *Kucoin) OrdersBook(symbol string, group, limit int) (ordersBook OrdersBook, err error) {
+	return b.ordersBook(symbol, group, limit, true)
+}
+
+// ordersBook requests open/orders, which is available without authentication as well.
+func (b *Kucoin) ordersBook(symbol string, group, limit int, auth bool) (ordersBook OrdersBook, err error) {
 	if len(symbol) < 1 {
 		return ordersBook, fmt.Errorf("The symbol is required")
 	}
 	payload := map[string]string{}
-	payload["symbol"] = strings.ToUpper(symbol)
+	if payload["symbol"], err = b.resolveSymbol(symbol); err != nil {
+		return
+	}
 	if group > 0 {
 		payload["group"] = fmt.Sprintf("%v", group)
 	}
@@ -328,7 +562,7 @@ func (b *Kucoin) OrdersBook(symbol string, group, limit int) (ordersBook OrdersB
 		payload["limit"] = fmt.Sprintf("%v", limit)
 	}
 
-	r, err := b.client.do("GET", "open/orders", payload, true)
+	r, err := b.client.do("GET", "open/orders", payload, auth)
 	if err != nil {
 		return
 	}
@@ -345,14 +579,200 @@ func (b *Kucoin) OrdersBook(symbol string, group, limit int) (ordersBook OrdersB
 	return
 }
 
+// BuyOrdersBook is used to get the buy side of orders book at Kucoin.
+// Symbol is required parameter, group and limit may be empty.
+func (b *Kucoin) BuyOrdersBook(symbol string, group, limit int) (OrdersBookSide, error) {
+	return b.ordersBookSide("open/orders-buy", symbol, group, limit)
+}
+
+// SellOrdersBook is used to get the sell side of orders book at Kucoin.
+// Symbol is required parameter, group and limit may be empty.
+func (b *Kucoin) SellOrdersBook(symbol string, group, limit int) (OrdersBookSide, error) {
+	return b.ordersBookSide("open/orders-sell", symbol, group, limit)
+}
+
+func (b *Kucoin) ordersBookSide(resource, symbol string, group, limit int) (side OrdersBookSide, err error) {
+	if len(symbol) < 1 {
+		return side, fmt.Errorf("The symbol is required")
+	}
+	payload := map[string]string{}
+	if payload["symbol"], err = b.resolveSymbol(symbol); err != nil {
+		return
+	}
+	if group > 0 {
+		payload["group"] = fmt.Sprintf("%v", group)
+	}
+	if limit == 0 {
+		payload["limit"] = fmt.Sprintf("%v", 1000)
+	} else {
+		payload["limit"] = fmt.Sprintf("%v", limit)
+	}
+
+	r, err := b.client.do("GET", resource, payload, false)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	var rawRes rawOrdersBookSide
+	err = json.Unmarshal(r, &rawRes)
+	side = rawRes.Data
+	return
+}
+
+// TopOfBook is used to get only the best bid and ask of symbol at Kucoin.
+// Authentication is not required.
+func (b *Kucoin) TopOfBook(symbol string) (top TopOfBook, err error) {
+	book, err := b.ordersBook(symbol, 0, 1, false)
+	if err != nil {
+		return
+	}
+	top.Symbol = normalizeSymbol(symbol)
+	if len(book.BUY) > 0 && len(book.BUY[0]) > 1 {
+		top.Bid, top.BidAmount = book.BUY[0][0], book.BUY[0][1]
+	}
+	if len(book.SELL) > 0 && len(book.SELL[0]) > 1 {
+		top.Ask, top.AskAmount = book.SELL[0][0], book.SELL[0][1]
+	}
+	return
+}
+
+// maxKlinesPerRequest is the number of candles requested at once by GetKlines.
+const maxKlinesPerRequest = 500
+
+// GetKlines is used to get the historical OHLCV candles of symbol at Kucoin.
+// Symbol and interval are required parameters. Large [from, to) ranges are split
+// into multiple requests, candles are returned in chronological order.
+func (b *Kucoin) GetKlines(ctx context.Context, symbol string, interval KlineInterval, from, to time.Time) (candles []Candle, err error) {
+	if len(symbol) < 1 {
+		return candles, fmt.Errorf("The symbol is required")
+	}
+	resolution, err := interval.resolution()
+	if err != nil {
+		return
+	}
+	if !from.Before(to) {
+		return candles, fmt.Errorf("The from time must be before to time")
+	}
+	step := interval.Duration() * maxKlinesPerRequest
+	for start := from; start.Before(to); start = start.Add(step) {
+		end := start.Add(step)
+		if end.After(to) {
+			end = to
+		}
+		var chunk []Candle
+		if chunk, err = b.getKlines(ctx, symbol, resolution, start, end); err != nil {
+			return nil, err
+		}
+		for _, c := range chunk {
+			if c.Time.Before(from) || !c.Time.Before(to) {
+				continue
+			}
+			if n := len(candles); n > 0 && !c.Time.After(candles[n-1].Time) {
+				continue
+			}
+			candles = append(candles, c)
+		}
+	}
+	return
+}
+
+func (b *Kucoin) getKlines(ctx context.Context, symbol, resolution string, from, to time.Time) (candles []Candle, err error) {
+	payload := map[string]string{}
+	if payload["symbol"], err = b.resolveSymbol(symbol); err != nil {
+		return
+	}
+	payload["resolution"] = resolution
+	payload["from"] = fmt.Sprintf("%v", from.Unix())
+	payload["to"] = fmt.Sprintf("%v", to.Unix())
+
+	r, err := b.client.doContext(ctx, "GET", "open/chart/history", payload, false)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	var rawRes rawKlines
+	if err = json.Unmarshal(r, &rawRes); err != nil {
+		return
+	}
+	return rawRes.candles()
+}
+
+// GetRecentTrades is used to get the recent public deals of symbol at Kucoin.
+// Symbol is required parameter. Limit may be zero and by default is equal to 100.
+// If since is not zero, only trades since that time are returned.
+// Trades are returned in chronological order.
+func (b *Kucoin) GetRecentTrades(symbol string, limit int, since time.Time) (trades []Trade, err error) {
+	if len(symbol) < 1 {
+		return trades, fmt.Errorf("The symbol is required")
+	}
+	payload := map[string]string{}
+	if payload["symbol"], err = b.resolveSymbol(symbol); err != nil {
+		return
+	}
+	if limit == 0 {
+		payload["limit"] = fmt.Sprintf("%v", 100)
+	} else {
+		payload["limit"] = fmt.Sprintf("%v", limit)
+	}
+	if !since.IsZero() {
+		payload["since"] = fmt.Sprintf("%v", since.UnixNano()/int64(time.Millisecond))
+	}
+
+	r, err := b.client.do("GET", "open/deal-orders", payload, false)
+	if err != nil {
+		return
+	}
+	var response interface{}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = handleErr(response); err != nil {
+		return
+	}
+	var rawRes rawTrades
+	if err = json.Unmarshal(r, &rawRes); err != nil {
+		return
+	}
+	return rawRes.trades()
+}
+
 // CreateOrder is used to create order at Kucoin along with other meta data.
+// If rounding is enabled with SetRounding, price and amount are fitted to symbol
+// precision before submission and error is returned if they fall below minimums.
 func (b *Kucoin) CreateOrder(symbol, side string, price, amount float64) (orderOid string, err error) {
 	payload := make(map[string]string)
-	payload["amount"] = strconv.FormatFloat(amount, 'f', 8, 64)
-	payload["price"] = strconv.FormatFloat(price, 'f', 8, 64)
+	if b.market != nil {
+		var rules OrderRules
+		if rules, err = b.market.OrderRules(symbol); err != nil {
+			return
+		}
+		if price, amount, err = rules.Apply(price, amount, b.roundMode); err != nil {
+			return
+		}
+		payload["amount"] = FormatPrecision(amount, rules.AmountPrecision)
+		payload["price"] = FormatPrecision(price, rules.PricePrecision)
+	} else {
+		payload["amount"] = strconv.FormatFloat(amount, 'f', 8, 64)
+		payload["price"] = strconv.FormatFloat(price, 'f', 8, 64)
+	}
 	payload["type"] = strings.ToUpper(side)
+	if symbol, err = b.resolveSymbol(symbol); err != nil {
+		return
+	}
 
-	r, err := b.client.do("POST", fmt.Sprintf("%s/order", strings.ToUpper(symbol)), payload, true)
+	r, err := b.client.do("POST", fmt.Sprintf("%s/order", symbol), payload, true)
 	if err != nil {
 		return
 	}
@@ -383,8 +803,11 @@ func (b *Kucoin) CreateOrderByString(symbol, side, price, amount string) (orderO
 	payload["amount"] = amount
 	payload["price"] = price
 	payload["type"] = strings.ToUpper(side)
+	if symbol, err = b.resolveSymbol(symbol); err != nil {
+		return
+	}
 
-	r, err := b.client.do("POST", fmt.Sprintf("%s/order", strings.ToUpper(symbol)), payload, true)
+	r, err := b.client.do("POST", fmt.Sprintf("%s/order", symbol), payload, true)
 	if err != nil {
 		return
 	}
@@ -459,7 +882,9 @@ func (b *Kucoin) ListSpecificDealtOrders(symbol, side string, limit, page int) (
 		return specificDealtOrders, fmt.Errorf("The not all required parameters are presented")
 	}
 	payload := map[string]string{}
-	payload["symbol"] = symbol
+	if payload["symbol"], err = parseSymbol(symbol); err != nil {
+		return
+	}
 	payload["type"] = side
 	if limit == 0 {
 		payload["limit"] = fmt.Sprintf("%v", 1000)
@@ -493,7 +918,9 @@ func (b *Kucoin) ListSpecificDealtOrders(symbol, side string, limit, page int) (
 func (b *Kucoin) ListMergedDealtOrders(symbol, side string, limit, page int, since, before int64) (mergedDealtOrders MergedDealtOrder, err error) {
 	payload := map[string]string{}
 	if len(symbol) > 1 {
-		payload["symbol"] = symbol
+		if payload["symbol"], err = parseSymbol(symbol); err != nil {
+			return
+		}
 	}
 	if len(side) > 1 {
 		payload["type"] = side
@@ -544,7 +971,9 @@ func (b *Kucoin) OrderDetails(symbol, side, orderOid string, limit, page int) (o
 	}
 	payload := map[string]string{}
 	payload["orderOid"] = orderOid
-	payload["symbol"] = symbol
+	if payload["symbol"], err = parseSymbol(symbol); err != nil {
+		return
+	}
 	payload["type"] = side
 	if limit == 0 {
 		payload["limit"] = fmt.Sprintf("%v", 20)
@@ -582,18 +1011,39 @@ func (b *Kucoin) OrderDetails(symbol, side, orderOid string, limit, page int) (o
 // - address = example_address
 // - amount 0.68
 // Result:
-// - Nothing.
+// - Withdrawal with Oid which is used to track or cancel it.
+// Use CreateWithdrawal for coins which require memo.
 func (b *Kucoin) CreateWithdrawalApply(coin, address string, amount float64) (withdrawalApply Withdrawal, err error) {
-	if len(coin) < 1 || len(address) < 1 || amount == 0 {
-		return withdrawalApply, fmt.Errorf("The not all required parameters are presented")
+	return b.CreateWithdrawal(WithdrawalRequest{Coin: coin, Address: address, Amount: amount})
+}
+
+// CreateWithdrawal is used to create withdrawal described by request
+// at Kucoin along with other meta data.
+// Coin, Address and Amount are required fields, Memo is optional.
+// Before submission address is checked by address validator and allow-list,
+// if they are set, withdrawal is validated with ValidateWithdrawal
+// and amount is rounded down to coin precision.
+func (b *Kucoin) CreateWithdrawal(req WithdrawalRequest) (withdrawal Withdrawal, err error) {
+	if len(req.Coin) < 1 || len(req.Address) < 1 || req.Amount == 0 {
+		return withdrawal, fmt.Errorf("The not all required parameters are presented")
+	}
+	if err = b.checkWithdrawalAddress(req); err != nil {
+		return
+	}
+	quote, err := b.ValidateWithdrawal(req.Coin, req.Amount)
+	if err != nil {
+		return
 	}
 	payload := map[string]string{}
-	payload["coin"] = coin
-	payload["address"] = address
-	payload["amount"] = fmt.Sprintf("%v", amount)
+	payload["coin"] = req.Coin
+	payload["address"] = req.Address
+	payload["amount"] = FormatPrecision(quote.Amount, quote.Precision)
+	if len(req.Memo) > 0 {
+		payload["memo"] = req.Memo
+	}
 
 	r, err := b.client.do("POST", fmt.Sprintf(
-		"account/%s/withdraw/apply", strings.ToUpper(coin)), payload, true)
+		"account/%s/withdraw/apply", strings.ToUpper(req.Coin)), payload, true)
 	if err != nil {
 		return
 	}
@@ -606,7 +1056,7 @@ func (b *Kucoin) CreateWithdrawalApply(coin, address string, amount float64) (wi
 	}
 	var rawRes rawWithdrawal
 	err = json.Unmarshal(r, &rawRes)
-	withdrawalApply = rawRes.Data
+	withdrawal = rawRes.Data
 	return
 }
 
@@ -617,7 +1067,7 @@ func (b *Kucoin) CreateWithdrawalApply(coin, address string, amount float64) (wi
 // - coin = KCS
 // - txOid = example_tx
 // Result:
-// - Nothing.
+// - Cancelled withdrawal.
 func (b *Kucoin) CancelWithdrawal(coin, txOid string) (withdrawal Withdrawal, err error) {
 	if len(coin) < 1 || len(txOid) < 1 {
 		return withdrawal, fmt.Errorf("The not all required parameters are presented")
@@ -653,7 +1103,7 @@ func (b *Kucoin) CancelOrder(orderOid, side, symbol string) error {
 	payload["orderOid"] = orderOid
 	payload["type"] = side
 
-	r, err := b.client.do("POST", fmt.Sprintf("%s/cancel-order", strings.ToUpper(symbol)), payload, true)
+	r, err := b.client.do("POST", fmt.Sprintf("%s/cancel-order", normalizeSymbol(symbol)), payload, true)
 	if err != nil {
 		return err
 	}
@@ -669,7 +1119,8 @@ func (b *Kucoin) CancelOrder(orderOid, side, symbol string) error {
 func (b *Kucoin) CancelAllOrders(symbol, side string) error {
 	payload := map[string]string{}
 	if len(symbol) > 1 {
-		payload["symbol"] = strings.ToUpper(symbol)
+		// Symbol is not validated against market cache, so orders of delisted symbols can be cancelled.
+		payload["symbol"] = normalizeSymbol(symbol)
 	}
 	if len(side) > 1 {
 		payload["type"] = side
@@ -0,0 +1,197 @@
+package kucoin
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of ledger entries.
+const (
+	LedgerTrade      = "TRADE"
+	LedgerDeposit    = "DEPOSIT"
+	LedgerWithdrawal = "WITHDRAW"
+)
+
+// LedgerEntry is a normalized movement of single asset.
+// Trade produces two entries, one per asset, with the same Reference.
+// Amount is positive for incoming and negative for outgoing funds.
+// Fee is paid in FeeAsset on top of Amount.
+type LedgerEntry struct {
+	Time      time.Time `json:"time"`
+	Type      string    `json:"type"`
+	Asset     string    `json:"asset"`
+	Amount    float64   `json:"amount"`
+	Fee       float64   `json:"fee"`
+	FeeAsset  string    `json:"feeAsset,omitempty"`
+	Reference string    `json:"reference"`
+	// Symbol, Side and Price are set for trade entries only.
+	Symbol string  `json:"symbol,omitempty"`
+	Side   string  `json:"side,omitempty"`
+	Price  float64 `json:"price,omitempty"`
+}
+
+// Symbol returns symbol of the deal, e.g. KCS-BTC.
+func (d DealtOrder) Symbol() string {
+	return strings.ToUpper(d.CoinType + "-" + d.CoinTypePair)
+}
+
+// LedgerEntries converts deal to two ledger entries. Fee is charged
+// in the received asset: base coin for BUY and quote coin for SELL.
+func (d DealtOrder) LedgerEntries() []LedgerEntry {
+	base, quote := strings.ToUpper(d.CoinType), strings.ToUpper(d.CoinTypePair)
+	side := strings.ToUpper(d.Direction)
+	entry := LedgerEntry{
+		Time:      msToTime(d.CreatedAt),
+		Type:      LedgerTrade,
+		Reference: d.Oid,
+		Symbol:    d.Symbol(),
+		Side:      side,
+		Price:     d.DealPrice,
+	}
+	baseEntry, quoteEntry := entry, entry
+	baseEntry.Asset, quoteEntry.Asset = base, quote
+	if side == "BUY" {
+		baseEntry.Amount, quoteEntry.Amount = d.Amount, -d.DealValue
+		baseEntry.Fee, baseEntry.FeeAsset = d.Fee, base
+	} else {
+		baseEntry.Amount, quoteEntry.Amount = -d.Amount, d.DealValue
+		quoteEntry.Fee, quoteEntry.FeeAsset = d.Fee, quote
+	}
+	return []LedgerEntry{baseEntry, quoteEntry}
+}
+
+// LedgerEntry converts finished deposit or withdrawal record to ledger entry.
+func (r AccountRecord) LedgerEntry() LedgerEntry {
+	coin := strings.ToUpper(r.CoinType)
+	e := LedgerEntry{
+		Time:      msToTime(r.CreatedAt),
+		Type:      strings.ToUpper(r.Type),
+		Asset:     coin,
+		Amount:    r.Amount,
+		Reference: r.Oid,
+	}
+	if e.Type == LedgerWithdrawal {
+		e.Amount = -r.Amount
+	}
+	if r.Fee != 0 {
+		e.Fee, e.FeeAsset = r.Fee, coin
+	}
+	return e
+}
+
+// LedgerExporter collects trades, deposits and withdrawals into single ledger.
+type LedgerExporter struct {
+	k *Kucoin
+	// Coins which deposits and withdrawals are exported.
+	// All coins listed at Kucoin are used if it is empty.
+	Coins []string
+}
+
+// NewLedgerExporter returns LedgerExporter of given coins.
+func NewLedgerExporter(k *Kucoin, coins ...string) *LedgerExporter {
+	return &LedgerExporter{k: k, Coins: coins}
+}
+
+// Fetch pulls all pages of dealt orders and finished deposit and withdrawal
+// records in [from, to) time range and returns them sorted by time.
+func (e *LedgerExporter) Fetch(from, to time.Time) (entries []LedgerEntry, err error) {
+	since, before := from.UnixNano()/int64(time.Millisecond), to.UnixNano()/int64(time.Millisecond)
+	inRange := func(ms int64) bool {
+		return ms >= since && ms < before
+	}
+
+	for page := 1; ; page++ {
+		var deals MergedDealtOrder
+		if deals, err = e.k.ListMergedDealtOrders("", "", 20, page, since, before); err != nil {
+			return
+		}
+		for _, d := range deals.Datas {
+			if inRange(d.CreatedAt) {
+				entries = append(entries, d.LedgerEntries()...)
+			}
+		}
+		if len(deals.Datas) < 20 {
+			break
+		}
+	}
+
+	coins := e.Coins
+	if len(coins) == 0 {
+		var all []Coin
+		if all, err = e.k.GetCoins(); err != nil {
+			return
+		}
+		for _, c := range all {
+			coins = append(coins, c.Coin)
+		}
+	}
+	for _, coin := range coins {
+		for _, side := range []string{LedgerDeposit, LedgerWithdrawal} {
+			for page := 1; ; page++ {
+				var history AccountHistory
+				if history, err = e.k.AccountHistory(coin, side, StatusFinished, 100, page); err != nil {
+					return
+				}
+				for _, r := range history.Datas {
+					if inRange(r.CreatedAt) {
+						entries = append(entries, r.LedgerEntry())
+					}
+				}
+				if len(history.Datas) == 0 || page >= history.PageNos {
+					break
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return
+}
+
+var ledgerCSVHeader = []string{
+	"time", "type", "asset", "amount", "fee", "fee_asset", "reference", "symbol", "side", "price",
+}
+
+// WriteLedgerCSV writes entries as CSV with header.
+func WriteLedgerCSV(w io.Writer, entries []LedgerEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ledgerCSVHeader); err != nil {
+		return err
+	}
+	formatFloat := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	for _, e := range entries {
+		price := ""
+		if e.Price != 0 {
+			price = formatFloat(e.Price)
+		}
+		if err := cw.Write([]string{
+			e.Time.UTC().Format(time.RFC3339Nano), e.Type, e.Asset,
+			formatFloat(e.Amount), formatFloat(e.Fee), e.FeeAsset, e.Reference,
+			e.Symbol, e.Side, price,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteLedgerJSONL writes entries as JSON Lines, one JSON object per line.
+func WriteLedgerJSONL(w io.Writer, entries []LedgerEntry) error {
+	enc := json.NewEncoder(w)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package kucoin
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// readLedgerCSV parses output of WriteLedgerCSV.
+func readLedgerCSV(r io.Reader) (entries []LedgerEntry, err error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return
+	}
+	parseFloat := func(s string) float64 {
+		v, _ := strconv.ParseFloat(s, 64)
+		return v
+	}
+	for _, row := range rows[1:] {
+		e := LedgerEntry{
+			Type: row[1], Asset: row[2], Amount: parseFloat(row[3]), Fee: parseFloat(row[4]),
+			FeeAsset: row[5], Reference: row[6], Symbol: row[7], Side: row[8], Price: parseFloat(row[9]),
+		}
+		if e.Time, err = time.Parse(time.RFC3339Nano, row[0]); err != nil {
+			return
+		}
+		entries = append(entries, e)
+	}
+	return
+}
+
+// readLedgerJSONL parses output of WriteLedgerJSONL.
+func readLedgerJSONL(r io.Reader) (entries []LedgerEntry, err error) {
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var e LedgerEntry
+		if err = dec.Decode(&e); err != nil {
+			return
+		}
+		entries = append(entries, e)
+	}
+	return
+}
+
+func TestLedgerRoundTrip(t *testing.T) {
+	orders := []DealtOrder{
+		{Oid: "1", CreatedAt: 1500000000123, CoinType: "KCS", CoinTypePair: "BTC", Direction: "BUY",
+			DealPrice: 0.0002, Amount: 100, DealValue: 0.02, Fee: 0.1},
+		{Oid: "2", CreatedAt: 1500000060456, CoinType: "KCS", CoinTypePair: "BTC", Direction: "SELL",
+			DealPrice: 0.00025, Amount: 50, DealValue: 0.0125, Fee: 0.0000125},
+	}
+	records := []AccountRecord{
+		{Oid: "3", CreatedAt: 1499999990000, CoinType: "btc", Type: "DEPOSIT", Amount: 1},
+		{Oid: "4", CreatedAt: 1500000120000, CoinType: "kcs", Type: "WITHDRAW", Amount: 40, Fee: 2},
+	}
+	var entries []LedgerEntry
+	for _, o := range orders {
+		entries = append(entries, o.LedgerEntries()...)
+	}
+	for _, r := range records {
+		entries = append(entries, r.LedgerEntry())
+	}
+
+	if e := records[0].LedgerEntry(); e.Asset != "BTC" || e.Amount != 1 || len(e.FeeAsset) != 0 {
+		t.Errorf("deposit entry = %+v, want 1 BTC without fee", e)
+	}
+	if e := records[1].LedgerEntry(); e.Type != LedgerWithdrawal || e.Amount != -40 || e.Fee != 2 || e.FeeAsset != "KCS" {
+		t.Errorf("withdrawal entry = %+v, want -40 KCS and 2 KCS fee", e)
+	}
+
+	tests := []struct {
+		name  string
+		write func(io.Writer, []LedgerEntry) error
+		read  func(io.Reader) ([]LedgerEntry, error)
+	}{
+		{"CSV", WriteLedgerCSV, readLedgerCSV},
+		{"JSONL", WriteLedgerJSONL, readLedgerJSONL},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := test.write(&buf, entries); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		read, err := test.read(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if len(read) != len(entries) {
+			t.Fatalf("%s: got %d entries, want %d", test.name, len(read), len(entries))
+		}
+
+		deals := DealsFromLedger(read)
+		if len(deals) != len(orders) {
+			t.Fatalf("%s: got %d deals, want %d", test.name, len(deals), len(orders))
+		}
+		for i, o := range orders {
+			got, want := deals[i], o.Deal()
+			if !got.Time.Equal(want.Time) {
+				t.Errorf("%s: deal %s time = %v, want %v", test.name, o.Oid, got.Time, want.Time)
+			}
+			got.Time, want.Time = time.Time{}, time.Time{}
+			if got != want {
+				t.Errorf("%s: deal %s = %+v, want %+v", test.name, o.Oid, got, want)
+			}
+		}
+	}
+}
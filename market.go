@@ -0,0 +1,21 @@
+package kucoin
+
+import "strings"
+
+type rawMarkets struct {
+	Success   bool     `json:"success"`
+	Code      string   `json:"code"`
+	Msg       string   `json:"msg"`
+	Timestamp int64    `json:"timestamp"`
+	Data      []string `json:"data"`
+}
+
+// GroupSymbolsByMarket groups symbols by market, i.e. by quote coin (CoinTypePair).
+func GroupSymbolsByMarket(symbols []Symbol) map[string][]Symbol {
+	markets := make(map[string][]Symbol)
+	for _, s := range symbols {
+		market := strings.ToUpper(s.CoinTypePair)
+		markets[market] = append(markets[market], s)
+	}
+	return markets
+}
@@ -0,0 +1,209 @@
+package kucoin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownSymbol is returned when symbol is not found in MarketInfo.
+var ErrUnknownSymbol = errors.New("Unknown symbol")
+
+// MarketInfo is a concurrency-safe cache of symbols and coins meta data.
+// Data is loaded on first use and refreshed after TTL expiration
+// or on demand with Refresh.
+type MarketInfo struct {
+	k   *Kucoin
+	ttl time.Duration
+
+	mu       sync.RWMutex
+	symbols  map[string]Symbol
+	coins    map[string]Coin
+	rules    map[string]OrderRules
+	loadedAt time.Time
+}
+
+// NewMarketInfo returns a MarketInfo cache which uses given Kucoin client.
+// Zero ttl means that data is loaded once and refreshed only on demand.
+func NewMarketInfo(k *Kucoin, ttl time.Duration) *MarketInfo {
+	return &MarketInfo{k: k, ttl: ttl}
+}
+
+// Refresh reloads symbols and coins from Kucoin.
+func (m *MarketInfo) Refresh() error {
+	symbols, err := m.k.GetSymbols()
+	if err != nil {
+		return err
+	}
+	coins, err := m.k.GetCoins()
+	if err != nil {
+		return err
+	}
+	symbolsMap := make(map[string]Symbol, len(symbols))
+	for _, s := range symbols {
+		symbolsMap[normalizeSymbol(s.Symbol)] = s
+	}
+	coinsMap := make(map[string]Coin, len(coins))
+	for _, c := range coins {
+		coinsMap[strings.ToUpper(c.Coin)] = c
+	}
+
+	m.mu.Lock()
+	m.symbols = symbolsMap
+	m.coins = coinsMap
+	m.loadedAt = time.Now()
+	m.mu.Unlock()
+	return nil
+}
+
+// LoadedAt returns the time of the last successful refresh.
+func (m *MarketInfo) LoadedAt() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.loadedAt
+}
+
+// ensure loads data if cache is empty or expired.
+func (m *MarketInfo) ensure() error {
+	m.mu.RLock()
+	fresh := m.symbols != nil && (m.ttl == 0 || time.Since(m.loadedAt) < m.ttl)
+	m.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+	return m.Refresh()
+}
+
+// Symbols returns all cached symbols.
+func (m *MarketInfo) Symbols() (symbols []Symbol, err error) {
+	if err = m.ensure(); err != nil {
+		return
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	symbols = make([]Symbol, 0, len(m.symbols))
+	for _, s := range m.symbols {
+		symbols = append(symbols, s)
+	}
+	return
+}
+
+// Symbol returns cached meta data of symbol, e.g. KCS-BTC.
+func (m *MarketInfo) Symbol(symbol string) (s Symbol, err error) {
+	if err = m.ensure(); err != nil {
+		return
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.symbols[normalizeSymbol(symbol)]
+	if !ok {
+		err = fmt.Errorf("%w %s", ErrUnknownSymbol, symbol)
+	}
+	return
+}
+
+// Coin returns cached meta data of coin, e.g. KCS.
+func (m *MarketInfo) Coin(coin string) (c Coin, err error) {
+	if err = m.ensure(); err != nil {
+		return
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.coins[strings.ToUpper(coin)]
+	if !ok {
+		err = fmt.Errorf("Unknown coin %s", coin)
+	}
+	return
+}
+
+// IsTradable reports whether trading is enabled for symbol.
+func (m *MarketInfo) IsTradable(symbol string) (bool, error) {
+	s, err := m.Symbol(symbol)
+	if err != nil {
+		return false, err
+	}
+	return s.Trading, nil
+}
+
+// PricePrecision returns number of decimals allowed for the price of symbol.
+// It is the trade precision of the quote coin (e.g. BTC for KCS-BTC).
+func (m *MarketInfo) PricePrecision(symbol string) (int, error) {
+	s, err := m.Symbol(symbol)
+	if err != nil {
+		return 0, err
+	}
+	c, err := m.Coin(s.CoinTypePair)
+	if err != nil {
+		return 0, err
+	}
+	return c.TradePrecision, nil
+}
+
+// AmountPrecision returns number of decimals allowed for the amount of symbol.
+// It is the trade precision of the base coin (e.g. KCS for KCS-BTC).
+func (m *MarketInfo) AmountPrecision(symbol string) (int, error) {
+	s, err := m.Symbol(symbol)
+	if err != nil {
+		return 0, err
+	}
+	c, err := m.Coin(s.CoinType)
+	if err != nil {
+		return 0, err
+	}
+	return c.TradePrecision, nil
+}
+
+// RoundPrice truncates price to the precision allowed for symbol.
+func (m *MarketInfo) RoundPrice(symbol string, price float64) (float64, error) {
+	precision, err := m.PricePrecision(symbol)
+	if err != nil {
+		return 0, err
+	}
+	return Round(price, precision, RoundDown), nil
+}
+
+// RoundAmount truncates amount to the precision allowed for symbol.
+func (m *MarketInfo) RoundAmount(symbol string, amount float64) (float64, error) {
+	precision, err := m.AmountPrecision(symbol)
+	if err != nil {
+		return 0, err
+	}
+	return Round(amount, precision, RoundDown), nil
+}
+
+// FeeRate returns trading fee rate of symbol.
+func (m *MarketInfo) FeeRate(symbol string) (float64, error) {
+	s, err := m.Symbol(symbol)
+	if err != nil {
+		return 0, err
+	}
+	return s.FeeRate, nil
+}
+
+// SetOrderRules overrides order rules of symbol, e.g. when exchange
+// announces tick or lot sizes which differ from coins precision.
+func (m *MarketInfo) SetOrderRules(symbol string, rules OrderRules) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.rules == nil {
+		m.rules = make(map[string]OrderRules)
+	}
+	m.rules[normalizeSymbol(symbol)] = rules
+}
+
+// OrderRules returns precision and minimums of orders for symbol.
+func (m *MarketInfo) OrderRules(symbol string) (rules OrderRules, err error) {
+	m.mu.RLock()
+	rules, ok := m.rules[normalizeSymbol(symbol)]
+	m.mu.RUnlock()
+	if ok {
+		return
+	}
+	if rules.PricePrecision, err = m.PricePrecision(symbol); err != nil {
+		return
+	}
+	rules.AmountPrecision, err = m.AmountPrecision(symbol)
+	return
+}
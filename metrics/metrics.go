@@ -0,0 +1,144 @@
+// Package metrics computes order book microstructure indicators
+// from Kucoin orders book snapshots.
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/eeonevision/kucoin-go"
+)
+
+// level returns price and amount of i-th level of book side,
+// where each level is [price, amount, volume].
+func level(side [][]float64, i int) (price, amount float64, ok bool) {
+	if i >= len(side) || len(side[i]) < 2 {
+		return 0, 0, false
+	}
+	return side[i][0], side[i][1], true
+}
+
+// Mid returns middle price between best bid and best ask, or zero if any side is empty.
+func Mid(book kucoin.OrdersBook) float64 {
+	bid, _, okBid := level(book.BUY, 0)
+	ask, _, okAsk := level(book.SELL, 0)
+	if !okBid || !okAsk {
+		return 0
+	}
+	return (bid + ask) / 2
+}
+
+// SpreadBps returns spread between best ask and best bid in basis points of mid price.
+func SpreadBps(book kucoin.OrdersBook) float64 {
+	bid, _, okBid := level(book.BUY, 0)
+	ask, _, okAsk := level(book.SELL, 0)
+	if !okBid || !okAsk || bid+ask == 0 {
+		return 0
+	}
+	return (ask - bid) / ((bid + ask) / 2) * 1e4
+}
+
+// WeightedMid returns mid price weighted by amounts at the best levels
+// (micro-price). It moves towards the side with smaller amount.
+func WeightedMid(book kucoin.OrdersBook) float64 {
+	bid, bidAmount, okBid := level(book.BUY, 0)
+	ask, askAmount, okAsk := level(book.SELL, 0)
+	if !okBid || !okAsk || bidAmount+askAmount == 0 {
+		return Mid(book)
+	}
+	return (bid*askAmount + ask*bidAmount) / (bidAmount + askAmount)
+}
+
+// Imbalance returns (bid - ask) / (bid + ask) of amounts at the first levels
+// of the book. Zero levels means the whole book. Result is in [-1, 1] range,
+// positive values mean buy pressure.
+func Imbalance(book kucoin.OrdersBook, levels int) float64 {
+	var bid, ask float64
+	for i := 0; levels <= 0 || i < levels; i++ {
+		_, b, okBid := level(book.BUY, i)
+		_, a, okAsk := level(book.SELL, i)
+		if !okBid && !okAsk {
+			break
+		}
+		bid += b
+		ask += a
+	}
+	if bid+ask == 0 {
+		return 0
+	}
+	return (bid - ask) / (bid + ask)
+}
+
+// DepthWithinBps returns amounts of bids and asks with price within bps basis points of mid price.
+func DepthWithinBps(book kucoin.OrdersBook, bps float64) (bid, ask float64) {
+	mid := Mid(book)
+	if mid == 0 {
+		return
+	}
+	distance := mid * bps / 1e4
+	for i := range book.BUY {
+		price, amount, ok := level(book.BUY, i)
+		if !ok || price < mid-distance {
+			break
+		}
+		bid += amount
+	}
+	for i := range book.SELL {
+		price, amount, ok := level(book.SELL, i)
+		if !ok || price > mid+distance {
+			break
+		}
+		ask += amount
+	}
+	return
+}
+
+// Slope returns slopes of bid and ask sides over the first levels of the book.
+// Slope is the least squares estimate of cumulative amount change per one
+// basis point of distance from mid price. Steeper slope means deeper book.
+func Slope(book kucoin.OrdersBook, levels int) (bid, ask float64) {
+	mid := Mid(book)
+	if mid == 0 {
+		return
+	}
+	return sideSlope(book.BUY, mid, levels), sideSlope(book.SELL, mid, levels)
+}
+
+func sideSlope(side [][]float64, mid float64, levels int) float64 {
+	var n, sumX, sumY, sumXY, sumXX, cumulative float64
+	for i := 0; levels <= 0 || i < levels; i++ {
+		price, amount, ok := level(side, i)
+		if !ok {
+			break
+		}
+		cumulative += amount
+		x := math.Abs(price-mid) / mid * 1e4
+		n++
+		sumX += x
+		sumY += cumulative
+		sumXY += x * cumulative
+		sumXX += x * x
+	}
+	d := n*sumXX - sumX*sumX
+	if n < 2 || d == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / d
+}
+
+// LoadBook reads orders book from recorded JSON. Both full API response
+// and its "data" object are accepted.
+func LoadBook(r io.Reader) (book kucoin.OrdersBook, err error) {
+	var raw struct {
+		kucoin.OrdersBook
+		Data *kucoin.OrdersBook `json:"data"`
+	}
+	if err = json.NewDecoder(r).Decode(&raw); err != nil {
+		return
+	}
+	if raw.Data != nil {
+		return *raw.Data, nil
+	}
+	return raw.OrdersBook, nil
+}
@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/eeonevision/kucoin-go"
+)
+
+func loadTestBook(t *testing.T) kucoin.OrdersBook {
+	t.Helper()
+	f, err := os.Open("testdata/book.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	book, err := LoadBook(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return book
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestLoadBook(t *testing.T) {
+	book := loadTestBook(t)
+	if len(book.BUY) != 3 || len(book.SELL) != 3 {
+		t.Fatalf("loaded %d bids and %d asks, want 3 and 3", len(book.BUY), len(book.SELL))
+	}
+	data, err := LoadBook(strings.NewReader(`{"BUY": [[1, 2, 2]], "SELL": []}`))
+	if err != nil || len(data.BUY) != 1 {
+		t.Errorf("LoadBook of data object = %+v, %v", data, err)
+	}
+}
+
+func TestMidAndSpread(t *testing.T) {
+	book := loadTestBook(t)
+	if got := Mid(book); !almostEqual(got, 100) {
+		t.Errorf("Mid = %v, want 100", got)
+	}
+	if got := SpreadBps(book); !almostEqual(got, 20) {
+		t.Errorf("SpreadBps = %v, want 20", got)
+	}
+	if got := Mid(kucoin.OrdersBook{BUY: book.BUY}); got != 0 {
+		t.Errorf("Mid of one-sided book = %v, want 0", got)
+	}
+}
+
+func TestWeightedMid(t *testing.T) {
+	book := loadTestBook(t)
+	// Best bid has twice the amount of best ask, so price leans towards the ask.
+	if got, want := WeightedMid(book), (99.9*1+100.1*2)/3; !almostEqual(got, want) {
+		t.Errorf("WeightedMid = %v, want %v", got, want)
+	}
+}
+
+func TestImbalance(t *testing.T) {
+	book := loadTestBook(t)
+	tests := []struct {
+		levels int
+		want   float64
+	}{
+		{1, 1.0 / 3},
+		{2, 2.0 / 8},
+		{0, 3.0 / 17},
+		{10, 3.0 / 17},
+	}
+	for _, tt := range tests {
+		if got := Imbalance(book, tt.levels); !almostEqual(got, tt.want) {
+			t.Errorf("Imbalance(%d) = %v, want %v", tt.levels, got, tt.want)
+		}
+	}
+	if got := Imbalance(kucoin.OrdersBook{}, 0); got != 0 {
+		t.Errorf("Imbalance of empty book = %v, want 0", got)
+	}
+}
+
+func TestDepthWithinBps(t *testing.T) {
+	book := loadTestBook(t)
+	tests := []struct {
+		bps      float64
+		bid, ask float64
+	}{
+		{5, 0, 0},
+		{15, 2, 1},
+		{25, 5, 3},
+		{100, 10, 7},
+	}
+	for _, tt := range tests {
+		bid, ask := DepthWithinBps(book, tt.bps)
+		if !almostEqual(bid, tt.bid) || !almostEqual(ask, tt.ask) {
+			t.Errorf("DepthWithinBps(%v) = %v, %v, want %v, %v", tt.bps, bid, ask, tt.bid, tt.ask)
+		}
+	}
+}
+
+func TestSlope(t *testing.T) {
+	book := loadTestBook(t)
+	tests := []struct {
+		levels   int
+		bid, ask float64
+	}{
+		// Cumulative amount grows by 3 bids and 2 asks over 10 bps.
+		{2, 0.3, 0.2},
+		// Least squares over points at 10, 20 and 100 bps.
+		{3, 1150.0 / 14600, (3*(10+60+700) - 130*(1+3+7)) / 14600.0},
+		{1, 0, 0},
+	}
+	for _, tt := range tests {
+		bid, ask := Slope(book, tt.levels)
+		if !almostEqual(bid, tt.bid) || !almostEqual(ask, tt.ask) {
+			t.Errorf("Slope(%d) = %v, %v, want %v, %v", tt.levels, bid, ask, tt.bid, tt.ask)
+		}
+	}
+}
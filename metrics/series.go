@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/eeonevision/kucoin-go"
+)
+
+// Snapshot holds indicators computed from single orders book.
+type Snapshot struct {
+	Time        time.Time
+	Mid         float64
+	WeightedMid float64
+	SpreadBps   float64
+	Imbalance   float64
+	BidDepth    float64
+	AskDepth    float64
+	BidSlope    float64
+	AskSlope    float64
+}
+
+// Config defines parameters of indicators computed by Series.
+type Config struct {
+	// Levels is the number of book levels used for imbalance and slope, zero means all levels.
+	Levels int
+	// DepthBps is the distance from mid price used for depth, in basis points.
+	DepthBps float64
+}
+
+// Compute returns indicators of book taken at time t.
+func (c Config) Compute(t time.Time, book kucoin.OrdersBook) Snapshot {
+	s := Snapshot{
+		Time:        t,
+		Mid:         Mid(book),
+		WeightedMid: WeightedMid(book),
+		SpreadBps:   SpreadBps(book),
+		Imbalance:   Imbalance(book, c.Levels),
+	}
+	s.BidDepth, s.AskDepth = DepthWithinBps(book, c.DepthBps)
+	s.BidSlope, s.AskSlope = Slope(book, c.Levels)
+	return s
+}
+
+// Series keeps indicators of the last orders book snapshots in a ring buffer.
+type Series struct {
+	config    Config
+	snapshots []Snapshot
+	next      int
+	full      bool
+}
+
+// NewSeries returns Series holding up to size snapshots.
+func NewSeries(size int, config Config) *Series {
+	if size < 1 {
+		size = 1
+	}
+	return &Series{config: config, snapshots: make([]Snapshot, size)}
+}
+
+// Add computes indicators of book taken at time t and appends them to the series.
+func (s *Series) Add(t time.Time, book kucoin.OrdersBook) Snapshot {
+	snapshot := s.config.Compute(t, book)
+	s.snapshots[s.next] = snapshot
+	s.next = (s.next + 1) % len(s.snapshots)
+	if s.next == 0 {
+		s.full = true
+	}
+	return snapshot
+}
+
+// Len returns number of snapshots in the series.
+func (s *Series) Len() int {
+	if s.full {
+		return len(s.snapshots)
+	}
+	return s.next
+}
+
+// At returns i-th snapshot, the oldest first.
+func (s *Series) At(i int) Snapshot {
+	if s.full {
+		i = (s.next + i) % len(s.snapshots)
+	}
+	return s.snapshots[i]
+}
+
+// Last returns the latest snapshot.
+func (s *Series) Last() (Snapshot, bool) {
+	if s.Len() == 0 {
+		return Snapshot{}, false
+	}
+	return s.At(s.Len() - 1), true
+}
+
+// Mean returns mean of the value selected by field over all snapshots.
+func (s *Series) Mean(field func(Snapshot) float64) float64 {
+	n := s.Len()
+	if n == 0 {
+		return 0
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		sum += field(s.At(i))
+	}
+	return sum / float64(n)
+}
+
+// MidReturn returns relative change of mid price from the oldest to the latest snapshot.
+func (s *Series) MidReturn() float64 {
+	n := s.Len()
+	if n < 2 || s.At(0).Mid == 0 {
+		return 0
+	}
+	return s.At(n-1).Mid/s.At(0).Mid - 1
+}
@@ -1,5 +1,7 @@
 package kucoin
 
+import "strings"
+
 // OrdersBook struct represents kucoin data model.
 type OrdersBook struct {
 	Comment string      `json:"_comment"`
@@ -13,3 +15,65 @@ type rawOrdersBook struct {
 	Msg     string     `json:"msg"`
 	Data    OrdersBook `json:"data"`
 }
+
+// OrdersBookSide is list of [price, amount, volume] levels of one side of the book.
+type OrdersBookSide [][]float64
+
+type rawOrdersBookSide struct {
+	Success bool           `json:"success"`
+	Code    string         `json:"code"`
+	Msg     string         `json:"msg"`
+	Data    OrdersBookSide `json:"data"`
+}
+
+// TopOfBook is the best bid and ask of symbol.
+// Prices and amounts are zero if corresponding side of the book is empty.
+type TopOfBook struct {
+	Symbol    string
+	Bid       float64
+	BidAmount float64
+	Ask       float64
+	AskAmount float64
+}
+
+// Spread returns difference between best ask and best bid.
+func (t TopOfBook) Spread() float64 {
+	return t.Ask - t.Bid
+}
+
+// Mid returns middle price between best ask and best bid.
+func (t TopOfBook) Mid() float64 {
+	return (t.Ask + t.Bid) / 2
+}
+
+// BookFill is a part of order executed against single level of orders book.
+type BookFill struct {
+	Price  float64
+	Amount float64
+}
+
+// Match returns fills of order with limit price and amount executed against
+// the opposite side of the book: SELL levels for BUY order and BUY levels for SELL order.
+// Levels are expected to be sorted from the best price, as returned by Kucoin.
+func (o OrdersBook) Match(side string, price, amount float64) (fills []BookFill) {
+	buy := strings.ToUpper(side) == "BUY"
+	levels := o.BUY
+	if buy {
+		levels = o.SELL
+	}
+	for _, l := range levels {
+		if amount <= 0 || len(l) < 2 {
+			break
+		}
+		if (buy && l[0] > price) || (!buy && l[0] < price) {
+			break
+		}
+		filled := l[1]
+		if filled > amount {
+			filled = amount
+		}
+		fills = append(fills, BookFill{Price: l[0], Amount: filled})
+		amount -= filled
+	}
+	return
+}
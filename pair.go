@@ -0,0 +1,108 @@
+package kucoin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Pair represents trading symbol, e.g. KCS-BTC where KCS is the base coin
+// and BTC is the quote coin. Pass Pair.String() to methods which accept symbol.
+type Pair struct {
+	Base  string
+	Quote string
+}
+
+// ParsePair parses symbol in KCS-BTC form. Lower case letters and
+// "_" or "/" separators are accepted as well. Surrounding spaces are ignored.
+func ParsePair(symbol string) (Pair, error) {
+	trimmed := strings.TrimSpace(symbol)
+	sep := strings.IndexAny(trimmed, "-_/")
+	if sep < 1 || sep == len(trimmed)-1 || strings.ContainsAny(trimmed[sep+1:], "-_/") ||
+		strings.ContainsAny(trimmed, " \t\r\n") {
+		return Pair{}, fmt.Errorf("Invalid symbol %q", symbol)
+	}
+	return Pair{Base: strings.ToUpper(trimmed[:sep]), Quote: strings.ToUpper(trimmed[sep+1:])}, nil
+}
+
+// String returns pair in Kucoin format, e.g. KCS-BTC.
+func (p Pair) String() string {
+	return p.Base + "-" + p.Quote
+}
+
+// IsZero reports whether pair is empty.
+func (p Pair) IsZero() bool {
+	return len(p.Base) == 0 && len(p.Quote) == 0
+}
+
+// MarshalText implements encoding.TextMarshaler, so Pair is marshalled
+// to JSON as "KCS-BTC" string and may be used as map key.
+func (p Pair) MarshalText() ([]byte, error) {
+	if p.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Pair) UnmarshalText(text []byte) (err error) {
+	if len(text) == 0 {
+		*p = Pair{}
+		return nil
+	}
+	*p, err = ParsePair(string(text))
+	return
+}
+
+// normalizeSymbol returns symbol in uniform KCS-BTC format.
+// Values which can't be parsed as pair are only upper-cased.
+func normalizeSymbol(symbol string) string {
+	p, err := ParsePair(symbol)
+	if err != nil {
+		return strings.ToUpper(symbol)
+	}
+	return p.String()
+}
+
+// parseSymbol parses symbol and returns it in KCS-BTC form.
+func parseSymbol(symbol string) (string, error) {
+	p, err := ParsePair(symbol)
+	if err != nil {
+		return "", err
+	}
+	return p.String(), nil
+}
+
+// resolveSymbol parses symbol and returns it in KCS-BTC form. If market cache
+// is set with SetMarketInfo, symbol is also checked to be listed at Kucoin.
+func (b *Kucoin) resolveSymbol(symbol string) (string, error) {
+	p, err := ParsePair(symbol)
+	if err != nil {
+		return "", err
+	}
+	if b.symbols != nil {
+		if err = b.symbols.ValidatePair(p); err != nil {
+			return "", err
+		}
+	}
+	return p.String(), nil
+}
+
+// ValidatePair checks that pair is listed at Kucoin. Cache is refreshed once
+// if pair is not found in it, as the pair may be listed after the cache was loaded.
+func (m *MarketInfo) ValidatePair(p Pair) error {
+	s, err := m.Symbol(p.String())
+	if errors.Is(err, ErrUnknownSymbol) {
+		if err = m.Refresh(); err != nil {
+			return err
+		}
+		s, err = m.Symbol(p.String())
+	}
+	if err != nil {
+		return err
+	}
+	if s.CoinType != p.Base || s.CoinTypePair != p.Quote {
+		return fmt.Errorf("Symbol %s doesn't match pair %s/%s", s.Symbol, p.Base, p.Quote)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package kucoin
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParsePair(t *testing.T) {
+	tests := []struct {
+		symbol  string
+		want    Pair
+		wantErr bool
+	}{
+		{symbol: "KCS-BTC", want: Pair{"KCS", "BTC"}},
+		{symbol: "kcs_btc", want: Pair{"KCS", "BTC"}},
+		{symbol: "Kcs/Btc", want: Pair{"KCS", "BTC"}},
+		{symbol: "  KCS-BTC\n", want: Pair{"KCS", "BTC"}},
+		{symbol: "KCS--BTC", wantErr: true},
+		{symbol: "-KCS-BTC-", wantErr: true},
+		{symbol: "-KCS", wantErr: true},
+		{symbol: "KCS-", wantErr: true},
+		{symbol: "KCS-BTC-ETH", wantErr: true},
+		{symbol: "KCS - BTC", wantErr: true},
+		{symbol: "KCSBTC", wantErr: true},
+		{symbol: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePair(tt.symbol)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePair(%q) err = %v, wantErr %v", tt.symbol, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePair(%q) = %+v, want %+v", tt.symbol, got, tt.want)
+		}
+	}
+}
+
+func TestPairText(t *testing.T) {
+	var p Pair
+	if err := p.UnmarshalText([]byte("eth/btc")); err != nil {
+		t.Fatal(err)
+	}
+	text, _ := p.MarshalText()
+	if string(text) != "ETH-BTC" {
+		t.Errorf("MarshalText = %q, want ETH-BTC", text)
+	}
+}
+
+func TestSymbolValidation(t *testing.T) {
+	listed := `{"symbol":"KCS-BTC","coinType":"KCS","coinTypePair":"BTC"}`
+	var refreshes int
+	var requested []string
+	k := newTestKucoin(func(r *http.Request) string {
+		switch r.URL.Path {
+		case "/v1/market/open/symbols":
+			refreshes++
+			if refreshes > 1 {
+				listed += `,{"symbol":"NEW-BTC","coinType":"NEW","coinTypePair":"BTC"}`
+			}
+			return `{"success":true,"data":[` + listed + `]}`
+		case "/v1/market/open/coins":
+			return `{"success":true,"data":[]}`
+		}
+		requested = append(requested, r.URL.Path+"?"+r.URL.RawQuery)
+		return `{"success":true,"data":{}}`
+	})
+	k.SetMarketInfo(NewMarketInfo(k, 0))
+
+	if _, err := k.GetSymbol("kcs/btc"); err != nil {
+		t.Fatal(err)
+	}
+	// Symbol listed after the cache was loaded is accepted after one refresh.
+	if _, err := k.GetSymbol("NEW-BTC"); err != nil || refreshes != 2 {
+		t.Fatalf("err = %v after %d refreshes, want listed symbol to be accepted", err, refreshes)
+	}
+	if _, err := k.GetSymbol("OLD-BTC"); !errors.Is(err, ErrUnknownSymbol) {
+		t.Errorf("err = %v, want ErrUnknownSymbol", err)
+	}
+	// History of delisted symbols is readable.
+	if _, err := k.ListMergedDealtOrders("OLD-BTC", "", 0, 0, 0, 0); err != nil {
+		t.Errorf("history of delisted symbol: %v", err)
+	}
+	if _, err := k.GetSymbol(""); err != nil {
+		t.Errorf("GetSymbol of all symbols: %v", err)
+	}
+	if n := len(requested); n != 4 || !strings.Contains(requested[0], "symbol=KCS-BTC") {
+		t.Errorf("requests = %q", requested)
+	}
+}
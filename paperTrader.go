@@ -0,0 +1,198 @@
+package kucoin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// paperOrdersBookLimit is the number of book levels PaperTrader matches orders against.
+const paperOrdersBookLimit = 50
+
+// PaperTrader implements Trader in memory. Market data is read from MarketData,
+// which may be live Kucoin client or ReplayMarket, while balances, active orders
+// and dealt orders are simulated by Simulator. Orders are matched against
+// the orders book when they are created and on every MatchOrders call.
+type PaperTrader struct {
+	market MarketData
+	sim    *Simulator
+	// FeeRate is used for symbols with zero fee rate.
+	FeeRate float64
+
+	mu       sync.Mutex
+	feeRates map[string]bool
+}
+
+// NewPaperTrader returns PaperTrader with initial balances keyed by coin.
+func NewPaperTrader(market MarketData, balances map[string]float64) *PaperTrader {
+	return &PaperTrader{
+		market:   market,
+		sim:      NewSimulator("paper", balances),
+		FeeRate:  0.001,
+		feeRates: make(map[string]bool),
+	}
+}
+
+// SetClock replaces the time source used for order and deal timestamps,
+// e.g. with replayed market time.
+func (p *PaperTrader) SetClock(now func() time.Time) {
+	p.sim.SetClock(now)
+}
+
+// OrdersBook returns orders book from market data.
+func (p *PaperTrader) OrdersBook(symbol string, group, limit int) (OrdersBook, error) {
+	return p.market.OrdersBook(symbol, group, limit)
+}
+
+// GetSymbol returns ticker from market data.
+func (p *PaperTrader) GetSymbol(market string) (Symbol, error) {
+	return p.market.GetSymbol(market)
+}
+
+// CreateOrder places simulated limit order. Funds are frozen and the order
+// is immediately matched against the orders book, the rest stays active.
+func (p *PaperTrader) CreateOrder(symbol, side string, price, amount float64) (orderOid string, err error) {
+	pair, err := ParsePair(symbol)
+	if err != nil {
+		return
+	}
+	if err = p.loadFeeRate(pair.String()); err != nil {
+		return
+	}
+	book, err := p.market.OrdersBook(pair.String(), 0, paperOrdersBookLimit)
+	if err != nil {
+		return
+	}
+	if orderOid, err = p.sim.CreateOrder(pair.String(), side, price, amount); err != nil {
+		return
+	}
+	p.sim.MatchBook(pair.String(), book)
+	return
+}
+
+// MatchOrders fetches orders books of symbols with active orders
+// and fills orders which cross the book.
+func (p *PaperTrader) MatchOrders() error {
+	for _, symbol := range p.sim.Symbols() {
+		if err := p.loadFeeRate(symbol); err != nil {
+			return err
+		}
+		book, err := p.market.OrdersBook(symbol, 0, paperOrdersBookLimit)
+		if err != nil {
+			return err
+		}
+		p.sim.MatchBook(symbol, book)
+	}
+	return nil
+}
+
+// CancelOrder cancels simulated order and releases its frozen funds.
+func (p *PaperTrader) CancelOrder(orderOid, side, symbol string) error {
+	return p.sim.CancelOrder(orderOid, side, symbol)
+}
+
+// CancelAllOrders cancels simulated orders. Symbol and side are optional filters.
+func (p *PaperTrader) CancelAllOrders(symbol, side string) error {
+	return p.sim.CancelAllOrders(symbol, side)
+}
+
+// ListActiveMapOrders returns simulated active orders of symbol. Side may be empty.
+func (p *PaperTrader) ListActiveMapOrders(symbol string, side string) (ActiveMapOrder, error) {
+	return p.sim.ListActiveMapOrders(symbol, side)
+}
+
+// GetCoinBalance returns simulated balance of coin.
+func (p *PaperTrader) GetCoinBalance(c string) (CoinBalance, error) {
+	return p.sim.GetCoinBalance(c)
+}
+
+// GetBalances returns simulated balances of all coins.
+func (p *PaperTrader) GetBalances(ctx context.Context, nonZero bool) (map[string]CoinBalance, error) {
+	return p.sim.GetBalances(ctx, nonZero)
+}
+
+// ListMergedDealtOrders returns simulated deals, the newest first.
+// All parameters are optional, as for Kucoin.ListMergedDealtOrders.
+func (p *PaperTrader) ListMergedDealtOrders(symbol, side string, limit, page int, since, before int64) (MergedDealtOrder, error) {
+	return p.sim.ListMergedDealtOrders(symbol, side, limit, page, since, before)
+}
+
+// loadFeeRate passes fee rate of symbol from market data to simulator
+// on the first use of symbol.
+func (p *PaperTrader) loadFeeRate(symbol string) error {
+	p.mu.Lock()
+	loaded := p.feeRates[symbol]
+	p.mu.Unlock()
+	if loaded {
+		return nil
+	}
+	s, err := p.market.GetSymbol(symbol)
+	if err != nil {
+		return err
+	}
+	rate := s.FeeRate
+	if rate == 0 {
+		rate = p.FeeRate
+	}
+	p.sim.SetFeeRate(symbol, rate)
+	p.mu.Lock()
+	p.feeRates[symbol] = true
+	p.mu.Unlock()
+	return nil
+}
+
+// ReplayMarket is MarketData holding the latest orders books and tickers
+// set by caller, e.g. from recorded data.
+type ReplayMarket struct {
+	mu      sync.RWMutex
+	books   map[string]OrdersBook
+	tickers map[string]Symbol
+}
+
+// NewReplayMarket returns empty ReplayMarket.
+func NewReplayMarket() *ReplayMarket {
+	return &ReplayMarket{books: make(map[string]OrdersBook), tickers: make(map[string]Symbol)}
+}
+
+// SetOrdersBook replaces orders book of symbol.
+func (m *ReplayMarket) SetOrdersBook(symbol string, book OrdersBook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.books[normalizeSymbol(symbol)] = book
+}
+
+// SetSymbol replaces ticker of symbol.
+func (m *ReplayMarket) SetSymbol(s Symbol) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tickers[normalizeSymbol(s.Symbol)] = s
+}
+
+// OrdersBook implements MarketData. Group is ignored.
+func (m *ReplayMarket) OrdersBook(symbol string, group, limit int) (ordersBook OrdersBook, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ordersBook, ok := m.books[normalizeSymbol(symbol)]
+	if !ok {
+		return ordersBook, fmt.Errorf("No orders book of %s", symbol)
+	}
+	if limit > 0 && len(ordersBook.BUY) > limit {
+		ordersBook.BUY = ordersBook.BUY[:limit]
+	}
+	if limit > 0 && len(ordersBook.SELL) > limit {
+		ordersBook.SELL = ordersBook.SELL[:limit]
+	}
+	return
+}
+
+// GetSymbol implements MarketData.
+func (m *ReplayMarket) GetSymbol(market string) (symbol Symbol, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	symbol, ok := m.tickers[normalizeSymbol(market)]
+	if !ok {
+		return symbol, fmt.Errorf("No ticker of %s", market)
+	}
+	return
+}
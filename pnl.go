@@ -0,0 +1,289 @@
+package kucoin
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// CostMethod defines how sold amount is matched against bought lots.
+type CostMethod int
+
+const (
+	// FIFO matches sells against the oldest lots first.
+	FIFO CostMethod = iota
+	// LIFO matches sells against the newest lots first.
+	LIFO
+	// AverageCost matches sells against average cost of the position.
+	AverageCost
+)
+
+// Deal is a single execution used by PnLEngine.
+// Fee is expressed in FeeAsset, which is either base or quote coin of the symbol.
+// Empty FeeAsset means quote coin.
+type Deal struct {
+	Symbol   string
+	Side     string
+	Time     time.Time
+	Price    float64
+	Amount   float64
+	Fee      float64
+	FeeAsset string
+}
+
+// Deal converts dealt order to Deal. Kucoin charges fee in the received coin:
+// base coin for BUY and quote coin for SELL.
+func (d DealtOrder) Deal() Deal {
+	deal := Deal{
+		Symbol:   d.Symbol(),
+		Side:     strings.ToUpper(d.Direction),
+		Time:     msToTime(d.CreatedAt),
+		Price:    d.DealPrice,
+		Amount:   d.Amount,
+		Fee:      d.Fee,
+		FeeAsset: strings.ToUpper(d.CoinTypePair),
+	}
+	if deal.Side == "BUY" {
+		deal.FeeAsset = strings.ToUpper(d.CoinType)
+	}
+	return deal
+}
+
+// feeInBase reports whether fee of deal is paid in base coin.
+func (d Deal) feeInBase() bool {
+	if len(d.FeeAsset) == 0 {
+		return false
+	}
+	pair, err := ParsePair(d.Symbol)
+	return err == nil && strings.EqualFold(d.FeeAsset, pair.Base)
+}
+
+// FeeQuote returns fee converted to quote coin by deal price.
+func (d Deal) FeeQuote() float64 {
+	if d.feeInBase() {
+		return d.Fee * d.Price
+	}
+	return d.Fee
+}
+
+// DealsFromLedger rebuilds deals from trade entries of ledger,
+// as produced by LedgerExporter.
+func DealsFromLedger(entries []LedgerEntry) (deals []Deal) {
+	index := map[string]int{}
+	for _, e := range entries {
+		if e.Type != LedgerTrade {
+			continue
+		}
+		i, ok := index[e.Reference]
+		if !ok {
+			i = len(deals)
+			index[e.Reference] = i
+			deals = append(deals, Deal{Symbol: e.Symbol, Side: e.Side, Time: e.Time, Price: e.Price})
+		}
+		pair, err := ParsePair(e.Symbol)
+		if err != nil {
+			continue
+		}
+		if e.Asset == pair.Base && e.Amount < 0 {
+			deals[i].Amount = -e.Amount
+		} else if e.Asset == pair.Base {
+			deals[i].Amount = e.Amount
+		}
+		if e.Fee != 0 {
+			deals[i].Fee, deals[i].FeeAsset = e.Fee, e.FeeAsset
+		}
+	}
+	return
+}
+
+// PnL is profit and loss of single symbol in its quote currency.
+type PnL struct {
+	Symbol string
+	// Position is the amount of base coin currently held.
+	Position float64
+	// AverageCost is fee-adjusted cost of one coin of the position.
+	AverageCost float64
+	Realized    float64
+	Fees        float64
+	// MarkPrice and Unrealized are set by Mark and MarkToMarket.
+	MarkPrice  float64
+	Unrealized float64
+	// Unmatched is the amount sold without matching bought lots,
+	// e.g. because deal history is incomplete. It is excluded from Realized.
+	Unmatched float64
+}
+
+type lot struct {
+	amount float64
+	cost   float64
+}
+
+type position struct {
+	lots       []lot
+	realized   float64
+	fees       float64
+	unmatched  float64
+	markPrice  float64
+	lastMarked bool
+}
+
+// PnLEngine calculates realized and unrealized PnL per symbol.
+// Deals must be added in chronological order.
+type PnLEngine struct {
+	method    CostMethod
+	positions map[string]*position
+}
+
+// NewPnLEngine returns PnLEngine using given cost method.
+func NewPnLEngine(method CostMethod) *PnLEngine {
+	return &PnLEngine{method: method, positions: make(map[string]*position)}
+}
+
+// Add processes single deal.
+func (e *PnLEngine) Add(d Deal) {
+	symbol := normalizeSymbol(d.Symbol)
+	p, ok := e.positions[symbol]
+	if !ok {
+		p = &position{}
+		e.positions[symbol] = p
+	}
+	if d.Amount <= 0 {
+		return
+	}
+	feeInBase := d.feeInBase()
+	p.fees += d.FeeQuote()
+
+	if strings.ToUpper(d.Side) == "BUY" {
+		// Fee in base coin reduces received amount, fee in quote coin increases cost.
+		l := lot{amount: d.Amount, cost: d.Price*d.Amount + d.Fee}
+		if feeInBase {
+			l = lot{amount: d.Amount - d.Fee, cost: d.Price * d.Amount}
+		}
+		if l.amount <= 0 {
+			return
+		}
+		l.cost /= l.amount
+		if e.method == AverageCost && len(p.lots) > 0 {
+			total := p.lots[0].amount + l.amount
+			p.lots[0].cost = (p.lots[0].amount*p.lots[0].cost + l.amount*l.cost) / total
+			p.lots[0].amount = total
+		} else {
+			p.lots = append(p.lots, l)
+		}
+		return
+	}
+
+	// Fee in base coin is disposed together with sold amount,
+	// fee in quote coin reduces proceeds.
+	disposed, proceeds := d.Amount, d.Price*d.Amount-d.Fee
+	if feeInBase {
+		disposed, proceeds = d.Amount+d.Fee, d.Price*d.Amount
+	}
+	remaining := disposed
+	var cost float64
+	for remaining > 1e-12 && len(p.lots) > 0 {
+		i := 0
+		if e.method == LIFO {
+			i = len(p.lots) - 1
+		}
+		matched := remaining
+		if p.lots[i].amount < matched {
+			matched = p.lots[i].amount
+		}
+		cost += matched * p.lots[i].cost
+		p.lots[i].amount -= matched
+		remaining -= matched
+		if p.lots[i].amount <= 1e-12 {
+			p.lots = append(p.lots[:i], p.lots[i+1:]...)
+		}
+	}
+	if remaining <= 1e-12 {
+		remaining = 0
+	}
+	p.unmatched += remaining
+	p.realized += proceeds*(disposed-remaining)/disposed - cost
+}
+
+// AddDeals sorts deals by time and processes them.
+func (e *PnLEngine) AddDeals(deals []Deal) {
+	sorted := append([]Deal(nil), deals...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+	for _, d := range sorted {
+		e.Add(d)
+	}
+}
+
+// AddDealtOrders processes dealt orders fetched from Kucoin.
+func (e *PnLEngine) AddDealtOrders(orders []DealtOrder) {
+	deals := make([]Deal, 0, len(orders))
+	for _, o := range orders {
+		deals = append(deals, o.Deal())
+	}
+	e.AddDeals(deals)
+}
+
+// AddLedger processes trade entries of exported ledger.
+func (e *PnLEngine) AddLedger(entries []LedgerEntry) {
+	e.AddDeals(DealsFromLedger(entries))
+}
+
+// Mark sets mark price used to calculate unrealized PnL of symbol.
+func (e *PnLEngine) Mark(symbol string, price float64) {
+	if p, ok := e.positions[normalizeSymbol(symbol)]; ok {
+		p.markPrice = price
+		p.lastMarked = true
+	}
+}
+
+// MarkToMarket marks all symbols against last deal price from Kucoin
+// and returns the report.
+func (e *PnLEngine) MarkToMarket(k *Kucoin) ([]PnL, error) {
+	for symbol := range e.positions {
+		s, err := k.GetSymbol(symbol)
+		if err != nil {
+			return nil, err
+		}
+		e.Mark(symbol, s.LastDealPrice)
+	}
+	return e.Report(), nil
+}
+
+// PnL returns profit and loss of symbol.
+func (e *PnLEngine) PnL(symbol string) PnL {
+	symbol = normalizeSymbol(symbol)
+	r := PnL{Symbol: symbol}
+	p, ok := e.positions[symbol]
+	if !ok {
+		return r
+	}
+	var cost float64
+	for _, l := range p.lots {
+		r.Position += l.amount
+		cost += l.amount * l.cost
+	}
+	if r.Position > 0 {
+		r.AverageCost = cost / r.Position
+	}
+	r.Realized = p.realized
+	r.Fees = p.fees
+	r.Unmatched = p.unmatched
+	if p.lastMarked {
+		r.MarkPrice = p.markPrice
+		r.Unrealized = r.Position*p.markPrice - cost
+	}
+	return r
+}
+
+// Report returns profit and loss of all symbols sorted by symbol.
+func (e *PnLEngine) Report() []PnL {
+	report := make([]PnL, 0, len(e.positions))
+	for symbol := range e.positions {
+		report = append(report, e.PnL(symbol))
+	}
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Symbol < report[j].Symbol
+	})
+	return report
+}
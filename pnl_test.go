@@ -0,0 +1,134 @@
+package kucoin
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestPnLEngine(t *testing.T) {
+	t0 := time.Unix(0, 0)
+	buy := func(i int, price, amount, fee float64) Deal {
+		return Deal{Symbol: "KCS-BTC", Side: "BUY", Time: t0.Add(time.Duration(i) * time.Second),
+			Price: price, Amount: amount, Fee: fee, FeeAsset: "KCS"}
+	}
+	sell := func(i int, price, amount, fee float64) Deal {
+		return Deal{Symbol: "KCS-BTC", Side: "SELL", Time: t0.Add(time.Duration(i) * time.Second),
+			Price: price, Amount: amount, Fee: fee, FeeAsset: "BTC"}
+	}
+
+	tests := []struct {
+		name      string
+		method    CostMethod
+		deals     []Deal
+		position  float64
+		realized  float64
+		fees      float64
+		unmatched float64
+	}{
+		{
+			name:     "buy fee in base coin, sell everything held",
+			method:   FIFO,
+			deals:    []Deal{buy(0, 1, 1, 0.001), sell(1, 1, 0.999, 0)},
+			position: 0,
+			realized: -0.001,
+			fees:     0.001,
+		},
+		{
+			name:     "buy and sell fees",
+			method:   FIFO,
+			deals:    []Deal{buy(0, 1, 1, 0.001), sell(1, 2, 0.999, 0.001998)},
+			position: 0,
+			realized: 2*0.999 - 0.001998 - 1,
+			fees:     0.001 + 0.001998,
+		},
+		{
+			name:     "fifo",
+			method:   FIFO,
+			deals:    []Deal{buy(0, 1, 1, 0), buy(1, 2, 1, 0), sell(2, 3, 1, 0)},
+			position: 1,
+			realized: 2,
+		},
+		{
+			name:     "lifo",
+			method:   LIFO,
+			deals:    []Deal{buy(0, 1, 1, 0), buy(1, 2, 1, 0), sell(2, 3, 1, 0)},
+			position: 1,
+			realized: 1,
+		},
+		{
+			name:     "average cost",
+			method:   AverageCost,
+			deals:    []Deal{buy(0, 1, 1, 0), buy(1, 2, 1, 0), sell(2, 3, 1, 0)},
+			position: 1,
+			realized: 1.5,
+		},
+		{
+			name:      "sell without history",
+			method:    FIFO,
+			deals:     []Deal{buy(0, 1, 1, 0), sell(1, 2, 3, 0)},
+			position:  0,
+			realized:  1,
+			unmatched: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewPnLEngine(tt.method)
+			e.AddDeals(tt.deals)
+			r := e.PnL("kcs-btc")
+			if !almostEqual(r.Position, tt.position) {
+				t.Errorf("Position = %v, want %v", r.Position, tt.position)
+			}
+			if !almostEqual(r.Realized, tt.realized) {
+				t.Errorf("Realized = %v, want %v", r.Realized, tt.realized)
+			}
+			if !almostEqual(r.Fees, tt.fees) {
+				t.Errorf("Fees = %v, want %v", r.Fees, tt.fees)
+			}
+			if !almostEqual(r.Unmatched, tt.unmatched) {
+				t.Errorf("Unmatched = %v, want %v", r.Unmatched, tt.unmatched)
+			}
+		})
+	}
+}
+
+func TestPnLEngineUnrealized(t *testing.T) {
+	e := NewPnLEngine(FIFO)
+	e.Add(Deal{Symbol: "KCS-BTC", Side: "BUY", Price: 1, Amount: 2, Fee: 0.002, FeeAsset: "KCS"})
+	e.Mark("KCS-BTC", 1.5)
+	r := e.PnL("KCS-BTC")
+	if !almostEqual(r.Position, 1.998) {
+		t.Errorf("Position = %v, want 1.998", r.Position)
+	}
+	if !almostEqual(r.Unrealized, 1.998*1.5-2) {
+		t.Errorf("Unrealized = %v, want %v", r.Unrealized, 1.998*1.5-2)
+	}
+}
+
+func TestDealtOrderDealFeeAsset(t *testing.T) {
+	buy := DealtOrder{CoinType: "KCS", CoinTypePair: "BTC", Direction: "BUY", DealPrice: 2, Amount: 1, Fee: 0.001}
+	if d := buy.Deal(); d.FeeAsset != "KCS" || !almostEqual(d.FeeQuote(), 0.002) {
+		t.Errorf("BUY deal fee = %v %s, quote %v", d.Fee, d.FeeAsset, d.FeeQuote())
+	}
+	sell := DealtOrder{CoinType: "KCS", CoinTypePair: "BTC", Direction: "SELL", DealPrice: 2, Amount: 1, Fee: 0.002}
+	if d := sell.Deal(); d.FeeAsset != "BTC" || !almostEqual(d.FeeQuote(), 0.002) {
+		t.Errorf("SELL deal fee = %v %s, quote %v", d.Fee, d.FeeAsset, d.FeeQuote())
+	}
+}
+
+func TestDealsFromLedger(t *testing.T) {
+	order := DealtOrder{Oid: "1", CoinType: "KCS", CoinTypePair: "BTC", Direction: "BUY",
+		DealPrice: 1, Amount: 1, DealValue: 1, Fee: 0.001}
+	deals := DealsFromLedger(order.LedgerEntries())
+	if len(deals) != 1 {
+		t.Fatalf("got %d deals, want 1", len(deals))
+	}
+	if d := deals[0]; d.Amount != 1 || d.Fee != 0.001 || d.FeeAsset != "KCS" || d.Side != "BUY" {
+		t.Errorf("got %+v", d)
+	}
+}
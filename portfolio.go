@@ -0,0 +1,134 @@
+package kucoin
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Portfolio values account balances in a chosen quote currency.
+type Portfolio struct {
+	k *Kucoin
+	// Intermediates are coins used to build conversion path when there is
+	// no direct pair between asset and quote currency.
+	Intermediates []string
+}
+
+// AssetValue is the value of a single coin balance.
+type AssetValue struct {
+	Coin   string
+	Amount float64
+	// Price is the price of one coin in quote currency.
+	Price float64
+	Value float64
+	// Route lists symbols used for conversion, e.g. [KCS-BTC BTC-USDT].
+	Route []string
+	// Priced is false when no conversion route was found.
+	Priced bool
+}
+
+// Valuation is the result of portfolio valuation.
+type Valuation struct {
+	Quote  string
+	Time   time.Time
+	Assets []AssetValue
+	Total  float64
+	// Unpriced lists coins which have no price route to quote currency.
+	Unpriced []string
+}
+
+// NewPortfolio returns Portfolio which uses BTC and ETH as intermediate coins.
+func NewPortfolio(k *Kucoin) *Portfolio {
+	return &Portfolio{k: k, Intermediates: []string{"BTC", "ETH"}}
+}
+
+// Value fetches all non-zero balances and last prices and values them in quote currency.
+// Assets are sorted by value in descending order.
+func (p *Portfolio) Value(ctx context.Context, quote string) (valuation Valuation, err error) {
+	balances, err := p.k.GetBalances(ctx, true)
+	if err != nil {
+		return
+	}
+	symbols, err := p.k.GetSymbols()
+	if err != nil {
+		return
+	}
+	return p.value(balances, symbols, quote), nil
+}
+
+func (p *Portfolio) value(balances map[string]CoinBalance, symbols []Symbol, quote string) (valuation Valuation) {
+	quote = strings.ToUpper(quote)
+	prices := newPriceGraph(symbols)
+	valuation.Quote = quote
+	valuation.Time = time.Now()
+	for coin, balance := range balances {
+		asset := AssetValue{Coin: coin, Amount: balance.Total()}
+		asset.Price, asset.Route, asset.Priced = prices.rate(coin, quote, p.Intermediates)
+		if asset.Priced {
+			asset.Value = asset.Amount * asset.Price
+			valuation.Total += asset.Value
+		} else {
+			valuation.Unpriced = append(valuation.Unpriced, coin)
+		}
+		valuation.Assets = append(valuation.Assets, asset)
+	}
+	sort.Slice(valuation.Assets, func(i, j int) bool {
+		if valuation.Assets[i].Value == valuation.Assets[j].Value {
+			return valuation.Assets[i].Coin < valuation.Assets[j].Coin
+		}
+		return valuation.Assets[i].Value > valuation.Assets[j].Value
+	})
+	sort.Strings(valuation.Unpriced)
+	return
+}
+
+type priceEdge struct {
+	price  float64
+	symbol string
+}
+
+// priceGraph holds last prices of coins in other coins, both direct and inverse.
+type priceGraph map[string]map[string]priceEdge
+
+func newPriceGraph(symbols []Symbol) priceGraph {
+	g := priceGraph{}
+	add := func(from, to string, price float64, symbol string) {
+		if g[from] == nil {
+			g[from] = map[string]priceEdge{}
+		}
+		g[from][to] = priceEdge{price: price, symbol: symbol}
+	}
+	for _, s := range symbols {
+		if s.LastDealPrice <= 0 {
+			continue
+		}
+		base, quote := strings.ToUpper(s.CoinType), strings.ToUpper(s.CoinTypePair)
+		add(base, quote, s.LastDealPrice, s.Symbol)
+		add(quote, base, 1/s.LastDealPrice, s.Symbol)
+	}
+	return g
+}
+
+// rate returns price of one coin in quote, directly or through one of intermediates.
+func (g priceGraph) rate(coin, quote string, intermediates []string) (float64, []string, bool) {
+	if coin == quote {
+		return 1, nil, true
+	}
+	if e, ok := g[coin][quote]; ok {
+		return e.price, []string{e.symbol}, true
+	}
+	for _, m := range intermediates {
+		m = strings.ToUpper(m)
+		first, ok := g[coin][m]
+		if !ok {
+			continue
+		}
+		second, ok := g[m][quote]
+		if !ok {
+			continue
+		}
+		return first.price * second.price, []string{first.symbol, second.symbol}, true
+	}
+	return 0, nil, false
+}
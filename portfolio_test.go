@@ -0,0 +1,62 @@
+package kucoin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPortfolioValue(t *testing.T) {
+	symbols := []Symbol{
+		{Symbol: "BTC-USDT", CoinType: "BTC", CoinTypePair: "USDT", LastDealPrice: 10000},
+		{Symbol: "ETH-USDT", CoinType: "ETH", CoinTypePair: "USDT", LastDealPrice: 500},
+		{Symbol: "USDT-TUSD", CoinType: "USDT", CoinTypePair: "TUSD", LastDealPrice: 1.25},
+		{Symbol: "KCS-BTC", CoinType: "KCS", CoinTypePair: "BTC", LastDealPrice: 0.0002},
+		{Symbol: "NEO-ETH", CoinType: "NEO", CoinTypePair: "ETH", LastDealPrice: 0.1},
+		{Symbol: "DOGE-XRP", CoinType: "DOGE", CoinTypePair: "XRP"},
+	}
+	balances := map[string]CoinBalance{
+		"BTC":  {CoinType: "BTC", Balance: 0.5, FreezeBalance: 0.5},
+		"TUSD": {CoinType: "TUSD", Balance: 10},
+		"KCS":  {CoinType: "KCS", Balance: 100},
+		"NEO":  {CoinType: "NEO", Balance: 2},
+		"USDT": {CoinType: "USDT", Balance: 3},
+		"DOGE": {CoinType: "DOGE", Balance: 1000},
+	}
+	p := &Portfolio{Intermediates: []string{"BTC", "ETH"}}
+	v := p.value(balances, symbols, "usdt")
+
+	tests := []struct {
+		coin  string
+		price float64
+		route []string
+	}{
+		{"BTC", 10000, []string{"BTC-USDT"}},
+		{"TUSD", 0.8, []string{"USDT-TUSD"}},
+		{"KCS", 2, []string{"KCS-BTC", "BTC-USDT"}},
+		{"NEO", 50, []string{"NEO-ETH", "ETH-USDT"}},
+		{"USDT", 1, nil},
+	}
+	assets := map[string]AssetValue{}
+	for _, a := range v.Assets {
+		assets[a.Coin] = a
+	}
+	for _, test := range tests {
+		a := assets[test.coin]
+		if !a.Priced || !almostEqual(a.Price, test.price) || !reflect.DeepEqual(a.Route, test.route) {
+			t.Errorf("%s = %+v, want price %v by %v", test.coin, a, test.price, test.route)
+		}
+		if !almostEqual(a.Value, a.Amount*test.price) {
+			t.Errorf("%s value = %v, want %v", test.coin, a.Value, a.Amount*test.price)
+		}
+	}
+
+	if v.Quote != "USDT" || !almostEqual(v.Total, 10000+8+200+100+3) {
+		t.Errorf("total = %v %s, want 10311 USDT", v.Total, v.Quote)
+	}
+	if !reflect.DeepEqual(v.Unpriced, []string{"DOGE"}) || assets["DOGE"].Priced {
+		t.Errorf("unpriced = %v, want [DOGE]", v.Unpriced)
+	}
+	if v.Assets[0].Coin != "BTC" || v.Assets[len(v.Assets)-1].Coin != "DOGE" {
+		t.Errorf("assets = %+v, want sorted by value", v.Assets)
+	}
+}
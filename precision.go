@@ -0,0 +1,68 @@
+package kucoin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// RoundMode defines how values are fitted to exchange precision.
+type RoundMode int
+
+const (
+	// RoundDown truncates extra decimals.
+	RoundDown RoundMode = iota
+	// RoundNearest rounds to the nearest allowed value, half away from zero.
+	RoundNearest
+)
+
+// ErrBelowMinimum is returned when rounded order value is below exchange minimums.
+var ErrBelowMinimum = errors.New("value is below minimum")
+
+// OrderRules describes precision and minimums of an order for specific symbol.
+type OrderRules struct {
+	PricePrecision  int
+	AmountPrecision int
+	// MinAmount is the smallest order amount. Zero means one lot, i.e. 10^-AmountPrecision.
+	MinAmount float64
+	// MinValue is the smallest price * amount of an order. Zero means no limit.
+	MinValue float64
+}
+
+// Round fits value into precision decimals according to mode.
+func Round(value float64, precision int, mode RoundMode) float64 {
+	p := math.Pow10(precision)
+	if mode == RoundNearest {
+		return math.Round(value*p) / p
+	}
+	// Small epsilon protects from binary representation errors, e.g. 0.29*100 = 28.999999999999996.
+	return math.Floor(value*p+1e-9) / p
+}
+
+// FormatPrecision returns value as a string with exactly precision decimals.
+func FormatPrecision(value float64, precision int) string {
+	return strconv.FormatFloat(value, 'f', precision, 64)
+}
+
+// Apply rounds price and amount according to rules and mode and checks minimums.
+func (r OrderRules) Apply(price, amount float64, mode RoundMode) (float64, float64, error) {
+	price = Round(price, r.PricePrecision, mode)
+	amount = Round(amount, r.AmountPrecision, mode)
+	if price <= 0 {
+		return price, amount, fmt.Errorf("%w: price %s", ErrBelowMinimum, FormatPrecision(price, r.PricePrecision))
+	}
+	minAmount := r.MinAmount
+	if minAmount == 0 {
+		minAmount = math.Pow10(-r.AmountPrecision)
+	}
+	if amount < minAmount {
+		return price, amount, fmt.Errorf("%w: amount %s, minimum %v",
+			ErrBelowMinimum, FormatPrecision(amount, r.AmountPrecision), minAmount)
+	}
+	if r.MinValue > 0 && price*amount < r.MinValue {
+		return price, amount, fmt.Errorf("%w: order value %v, minimum %v",
+			ErrBelowMinimum, price*amount, r.MinValue)
+	}
+	return price, amount, nil
+}
@@ -0,0 +1,84 @@
+package kucoin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		value     float64
+		precision int
+		mode      RoundMode
+		want      float64
+	}{
+		{0.29, 2, RoundDown, 0.29},
+		{1.23456789, 4, RoundDown, 1.2345},
+		{1.23456789, 4, RoundNearest, 1.2346},
+		{0.125, 2, RoundNearest, 0.13},
+		{0.1299999, 2, RoundDown, 0.12},
+		{12345.6789, 0, RoundDown, 12345},
+		{12345.6789, 0, RoundNearest, 12346},
+		{0.00000001, 8, RoundDown, 0.00000001},
+		{0, 6, RoundDown, 0},
+	}
+	for _, tt := range tests {
+		if got := Round(tt.value, tt.precision, tt.mode); !almostEqual(got, tt.want) {
+			t.Errorf("Round(%v, %d, %v) = %v, want %v", tt.value, tt.precision, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestFormatPrecision(t *testing.T) {
+	tests := []struct {
+		value     float64
+		precision int
+		want      string
+	}{
+		{0.1, 8, "0.10000000"},
+		{1e-8, 8, "0.00000001"},
+		{123.456, 2, "123.46"},
+		{5, 0, "5"},
+	}
+	for _, tt := range tests {
+		if got := FormatPrecision(tt.value, tt.precision); got != tt.want {
+			t.Errorf("FormatPrecision(%v, %d) = %q, want %q", tt.value, tt.precision, got, tt.want)
+		}
+	}
+}
+
+func TestOrderRulesApply(t *testing.T) {
+	rules := OrderRules{PricePrecision: 6, AmountPrecision: 4}
+	tests := []struct {
+		name       string
+		rules      OrderRules
+		price      float64
+		amount     float64
+		mode       RoundMode
+		wantPrice  float64
+		wantAmount float64
+		wantErr    bool
+	}{
+		{"round down", rules, 0.12345678, 1.23456, RoundDown, 0.123456, 1.2345, false},
+		{"round nearest", rules, 0.12345678, 1.23456, RoundNearest, 0.123457, 1.2346, false},
+		{"price rounds to zero", rules, 0.0000001, 1, RoundDown, 0, 1, true},
+		{"amount below one lot", rules, 1, 0.00009, RoundDown, 1, 0, true},
+		{"amount below minimum", OrderRules{PricePrecision: 2, AmountPrecision: 2, MinAmount: 1}, 1, 0.5, RoundDown, 1, 0.5, true},
+		{"value below minimum", OrderRules{PricePrecision: 2, AmountPrecision: 2, MinValue: 10}, 2, 4, RoundDown, 2, 4, true},
+		{"value at minimum", OrderRules{PricePrecision: 2, AmountPrecision: 2, MinValue: 10}, 2, 5, RoundDown, 2, 5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, amount, err := tt.rules.Apply(tt.price, tt.amount, tt.mode)
+			if !almostEqual(price, tt.wantPrice) || !almostEqual(amount, tt.wantAmount) {
+				t.Errorf("Apply = %v, %v, want %v, %v", price, amount, tt.wantPrice, tt.wantAmount)
+			}
+			if tt.wantErr && !errors.Is(err, ErrBelowMinimum) {
+				t.Errorf("err = %v, want ErrBelowMinimum", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected err: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package kucoin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Simulator is the in-memory matching engine used by PaperTrader and backtest.
+// It keeps balances, active orders and dealt orders, and fills orders against
+// orders books and trades passed by caller. Its order and balance methods
+// have the same signatures as methods of Kucoin.
+type Simulator struct {
+	// FeeRate is used for symbols without fee rate set by SetFeeRate.
+	FeeRate float64
+	// Latency is the delay of order creation and cancellation.
+	// Orders are not matched and cancellations don't take effect until it passes.
+	Latency time.Duration
+
+	mu       sync.Mutex
+	prefix   string
+	now      func() time.Time
+	balances map[string]float64
+	frozen   map[string]float64
+	feeRates map[string]float64
+	orders   map[string]*simOrder
+	deals    []DealtOrder
+	seq      int
+}
+
+type simOrder struct {
+	MapOrder
+	pair     Pair
+	seq      int
+	activeAt time.Time
+	cancelAt time.Time
+}
+
+// NewSimulator returns Simulator with initial balances keyed by coin.
+// Prefix is prepended to ids of simulated orders and deals.
+func NewSimulator(prefix string, balances map[string]float64) *Simulator {
+	s := &Simulator{
+		FeeRate:  0.001,
+		prefix:   prefix,
+		now:      time.Now,
+		balances: make(map[string]float64),
+		frozen:   make(map[string]float64),
+		feeRates: make(map[string]float64),
+		orders:   make(map[string]*simOrder),
+	}
+	for coin, amount := range balances {
+		s.balances[strings.ToUpper(coin)] = amount
+	}
+	return s
+}
+
+// SetClock replaces the time source used for order and deal timestamps,
+// e.g. with replayed market time.
+func (s *Simulator) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// SetFeeRate sets fee rate of symbol.
+func (s *Simulator) SetFeeRate(symbol string, rate float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.feeRates[normalizeSymbol(symbol)] = rate
+}
+
+// CreateOrder places simulated limit order and freezes its funds.
+// Order is matched by the following MatchBook and MatchTrade calls.
+func (s *Simulator) CreateOrder(symbol, side string, price, amount float64) (orderOid string, err error) {
+	pair, err := ParsePair(symbol)
+	if err != nil {
+		return
+	}
+	side = strings.ToUpper(side)
+	if side != "BUY" && side != "SELL" {
+		return "", fmt.Errorf("Unknown order side %s", side)
+	}
+	if price <= 0 || amount <= 0 {
+		return "", fmt.Errorf("Price and amount must be positive")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coin, value := pair.Base, amount
+	if side == "BUY" {
+		coin, value = pair.Quote, price*amount
+	}
+	if s.balances[coin]-s.frozen[coin] < value {
+		return "", fmt.Errorf("Insufficient balance of %s", coin)
+	}
+	s.frozen[coin] += value
+
+	s.seq++
+	now := s.now()
+	o := &simOrder{
+		MapOrder: MapOrder{
+			Oid:           fmt.Sprintf("%s-%d", s.prefix, s.seq),
+			Type:          side,
+			CoinType:      pair.Base,
+			CoinTypePair:  pair.Quote,
+			Direction:     side,
+			Price:         price,
+			PendingAmount: amount,
+			CreatedAt:     timeToMs(now),
+			UpdatedAt:     timeToMs(now),
+		},
+		pair:     pair,
+		seq:      s.seq,
+		activeAt: now.Add(s.Latency),
+	}
+	s.orders[o.Oid] = o
+	return o.Oid, nil
+}
+
+// CancelOrder cancels simulated order and releases its frozen funds
+// once Latency passes. Order may still be filled until then.
+func (s *Simulator) CancelOrder(orderOid, side, symbol string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orders[orderOid]
+	if !ok {
+		return fmt.Errorf("Order %s is not found", orderOid)
+	}
+	s.cancel(o)
+	s.processCancellations()
+	return nil
+}
+
+// CancelAllOrders cancels simulated orders. Symbol and side are optional filters.
+func (s *Simulator) CancelAllOrders(symbol, side string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range s.sortedOrders(symbol, side) {
+		s.cancel(o)
+	}
+	s.processCancellations()
+	return nil
+}
+
+// ProcessCancellations removes cancelled orders which cancellation reached the exchange.
+func (s *Simulator) ProcessCancellations() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.processCancellations()
+}
+
+// MatchBook fills active orders of symbol which cross the book, the oldest orders first.
+// Liquidity consumed by older orders is not available to the newer ones.
+// The book passed by caller is not modified.
+func (s *Simulator) MatchBook(symbol string, book OrdersBook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.processCancellations()
+	book.BUY, book.SELL = copyLevels(book.BUY), copyLevels(book.SELL)
+	for _, o := range s.activeOrders(symbol) {
+		levels := book.BUY
+		if o.Type == "BUY" {
+			levels = book.SELL
+		}
+		// Match returns fills of consecutive levels starting from the best one.
+		for i, f := range book.Match(o.Type, o.Price, o.PendingAmount) {
+			levels[i][1] -= f.Amount
+			s.fill(o, f.Price, f.Amount)
+		}
+	}
+}
+
+// MatchTrade fills active orders of symbol which price is crossed by public trade,
+// at the order price, the oldest orders first. Orders share the traded amount.
+func (s *Simulator) MatchTrade(symbol string, t Trade) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.processCancellations()
+	left := t.Amount
+	for _, o := range s.activeOrders(symbol) {
+		if left <= 0 {
+			break
+		}
+		if (o.Type == "BUY" && t.Price <= o.Price) || (o.Type == "SELL" && t.Price >= o.Price) {
+			amount := left
+			if amount > o.PendingAmount {
+				amount = o.PendingAmount
+			}
+			left -= amount
+			s.fill(o, o.Price, amount)
+		}
+	}
+}
+
+// Symbols returns symbols which have active orders.
+func (s *Simulator) Symbols() (symbols []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := map[string]bool{}
+	for _, o := range s.sortedOrders("", "") {
+		if symbol := o.pair.String(); !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	return
+}
+
+// ListActiveMapOrders returns simulated active orders of symbol. Side may be empty.
+func (s *Simulator) ListActiveMapOrders(symbol string, side string) (activeMapOrders ActiveMapOrder, err error) {
+	if len(symbol) < 1 {
+		return activeMapOrders, fmt.Errorf("Symbol is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range s.sortedOrders(symbol, side) {
+		if o.Type == "BUY" {
+			activeMapOrders.BUY = append(activeMapOrders.BUY, o.MapOrder)
+		} else {
+			activeMapOrders.SELL = append(activeMapOrders.SELL, o.MapOrder)
+		}
+	}
+	return
+}
+
+// GetCoinBalance returns simulated balance of coin.
+func (s *Simulator) GetCoinBalance(c string) (CoinBalance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c = strings.ToUpper(c)
+	return CoinBalance{CoinType: c, Balance: s.balances[c] - s.frozen[c], FreezeBalance: s.frozen[c]}, nil
+}
+
+// GetBalances returns simulated balances of all coins.
+func (s *Simulator) GetBalances(ctx context.Context, nonZero bool) (map[string]CoinBalance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	balances := make(map[string]CoinBalance, len(s.balances))
+	for coin, amount := range s.balances {
+		if nonZero && amount == 0 && s.frozen[coin] == 0 {
+			continue
+		}
+		balances[coin] = CoinBalance{CoinType: coin, Balance: amount - s.frozen[coin], FreezeBalance: s.frozen[coin]}
+	}
+	return balances, nil
+}
+
+// Balances returns simulated balances of all coins, including frozen amounts.
+func (s *Simulator) Balances() map[string]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	balances := make(map[string]float64, len(s.balances))
+	for coin, amount := range s.balances {
+		balances[coin] = amount
+	}
+	return balances
+}
+
+// Deals returns all simulated deals in chronological order.
+func (s *Simulator) Deals() []DealtOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]DealtOrder(nil), s.deals...)
+}
+
+// ListMergedDealtOrders returns simulated deals, the newest first.
+// All parameters are optional, as for Kucoin.ListMergedDealtOrders.
+func (s *Simulator) ListMergedDealtOrders(symbol, side string, limit, page int, since, before int64) (mergedDealtOrders MergedDealtOrder, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	symbol, side = normalizeSymbol(symbol), strings.ToUpper(side)
+	var deals []DealtOrder
+	for i := len(s.deals) - 1; i >= 0; i-- {
+		d := s.deals[i]
+		if (len(symbol) > 1 && d.Symbol() != symbol) || (len(side) > 1 && d.Direction != side) ||
+			(since != 0 && d.CreatedAt < since) || (before != 0 && d.CreatedAt >= before) {
+			continue
+		}
+		deals = append(deals, d)
+	}
+	if limit == 0 {
+		limit = 20
+	}
+	if page == 0 {
+		page = 1
+	}
+	mergedDealtOrders.Total = len(deals)
+	mergedDealtOrders.Limit = limit
+	mergedDealtOrders.Page = page
+	if start := (page - 1) * limit; start < len(deals) {
+		end := start + limit
+		if end > len(deals) {
+			end = len(deals)
+		}
+		mergedDealtOrders.Datas = deals[start:end]
+	}
+	return
+}
+
+// sortedOrders returns orders filtered by optional symbol and side, the oldest first.
+func (s *Simulator) sortedOrders(symbol, side string) (orders []*simOrder) {
+	symbol, side = normalizeSymbol(symbol), strings.ToUpper(side)
+	for _, o := range s.orders {
+		if (len(symbol) > 1 && o.pair.String() != symbol) || (len(side) > 1 && o.Type != side) {
+			continue
+		}
+		orders = append(orders, o)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].seq < orders[j].seq
+	})
+	return
+}
+
+// activeOrders returns orders of symbol which reached the exchange, the oldest first.
+func (s *Simulator) activeOrders(symbol string) (orders []*simOrder) {
+	now := s.now()
+	for _, o := range s.sortedOrders(symbol, "") {
+		if !o.activeAt.After(now) {
+			orders = append(orders, o)
+		}
+	}
+	return
+}
+
+// fill executes amount of order at price. Fee is charged in received coin.
+func (s *Simulator) fill(o *simOrder, price, amount float64) {
+	if amount <= 0 {
+		return
+	}
+	rate, ok := s.feeRates[o.pair.String()]
+	if !ok {
+		rate = s.FeeRate
+	}
+	s.seq++
+	deal := DealtOrder{
+		CreatedAt:     timeToMs(s.now()),
+		Amount:        amount,
+		DealValue:     price * amount,
+		DealPrice:     price,
+		FeeRate:       rate,
+		Oid:           fmt.Sprintf("%s-deal-%d", s.prefix, s.seq),
+		OrderOid:      o.Oid,
+		CoinType:      o.CoinType,
+		CoinTypePair:  o.CoinTypePair,
+		Direction:     o.Type,
+		DealDirection: o.Type,
+	}
+	if o.Type == "BUY" {
+		deal.Fee = amount * rate
+		s.balances[o.CoinTypePair] -= deal.DealValue
+		s.balances[o.CoinType] += amount - deal.Fee
+	} else {
+		deal.Fee = deal.DealValue * rate
+		s.balances[o.CoinType] -= amount
+		s.balances[o.CoinTypePair] += deal.DealValue - deal.Fee
+	}
+	s.unfreeze(o, amount)
+	s.deals = append(s.deals, deal)
+	o.DealAmount += amount
+	o.PendingAmount -= amount
+	o.UpdatedAt = deal.CreatedAt
+	if o.PendingAmount <= 1e-12 {
+		delete(s.orders, o.Oid)
+	}
+}
+
+// cancel requests cancellation of order, which takes effect after Latency.
+func (s *Simulator) cancel(o *simOrder) {
+	if o.cancelAt.IsZero() {
+		o.cancelAt = s.now().Add(s.Latency)
+	}
+}
+
+func (s *Simulator) processCancellations() {
+	now := s.now()
+	for oid, o := range s.orders {
+		if !o.cancelAt.IsZero() && !o.cancelAt.After(now) {
+			s.unfreeze(o, o.PendingAmount)
+			delete(s.orders, oid)
+		}
+	}
+}
+
+// unfreeze releases funds frozen for amount of order.
+func (s *Simulator) unfreeze(o *simOrder, amount float64) {
+	if o.Type == "BUY" {
+		s.frozen[o.CoinTypePair] -= o.Price * amount
+	} else {
+		s.frozen[o.CoinType] -= amount
+	}
+}
+
+// copyLevels returns deep copy of orders book levels.
+func copyLevels(levels [][]float64) [][]float64 {
+	res := make([][]float64, len(levels))
+	for i, l := range levels {
+		res[i] = append([]float64(nil), l...)
+	}
+	return res
+}
+
+// timeToMs converts time to Kucoin timestamp in milliseconds.
+func timeToMs(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,127 @@
+package kucoin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulatorLatency(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	s := NewSimulator("test", map[string]float64{"BTC": 1})
+	s.FeeRate = 0
+	s.Latency = time.Second
+	s.SetClock(func() time.Time { return now })
+
+	book := OrdersBook{SELL: [][]float64{{0.5, 1, 0.5}}}
+	oid, err := s.CreateOrder("KCS-BTC", "BUY", 0.5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := s.GetCoinBalance("BTC"); b.Balance != 0.5 || b.FreezeBalance != 0.5 {
+		t.Errorf("BTC balance = %+v, want 0.5 free and 0.5 frozen", b)
+	}
+	s.MatchBook("KCS-BTC", book)
+	if len(s.Deals()) != 0 {
+		t.Fatal("order is matched before latency passed")
+	}
+
+	now = now.Add(time.Second)
+	if err = s.CancelOrder(oid, "BUY", "KCS-BTC"); err != nil {
+		t.Fatal(err)
+	}
+	// Cancellation has not reached the exchange yet, so order is filled.
+	s.MatchTrade("KCS-BTC", Trade{Time: now, Price: 0.4, Amount: 0.4})
+	now = now.Add(time.Second)
+	s.ProcessCancellations()
+
+	deals := s.Deals()
+	if len(deals) != 1 || deals[0].Amount != 0.4 || deals[0].DealPrice != 0.5 {
+		t.Fatalf("deals = %+v, want 0.4 at 0.5", deals)
+	}
+	if orders, _ := s.ListActiveMapOrders("KCS-BTC", ""); len(orders.BUY) != 0 {
+		t.Errorf("active orders = %+v, want none", orders)
+	}
+	balances := s.Balances()
+	if !almostEqual(balances["BTC"], 0.8) || !almostEqual(balances["KCS"], 0.4) {
+		t.Errorf("balances = %v, want 0.8 BTC and 0.4 KCS", balances)
+	}
+	if b, _ := s.GetCoinBalance("BTC"); !almostEqual(b.FreezeBalance, 0) {
+		t.Errorf("BTC frozen = %v, want 0", b.FreezeBalance)
+	}
+}
+
+func TestPaperTrader(t *testing.T) {
+	market := NewReplayMarket()
+	market.SetSymbol(Symbol{Symbol: "KCS-BTC", FeeRate: 0.001})
+	market.SetOrdersBook("KCS-BTC", OrdersBook{
+		BUY:  [][]float64{{0.9, 10, 9}},
+		SELL: [][]float64{{1, 1, 1}, {1.1, 10, 11}},
+	})
+	p := NewPaperTrader(market, map[string]float64{"BTC": 10})
+
+	if _, err := p.CreateOrder("KCS-BTC", "BUY", 1.05, 2); err != nil {
+		t.Fatal(err)
+	}
+	orders, err := p.ListActiveMapOrders("KCS-BTC", "BUY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders.BUY) != 1 || !almostEqual(orders.BUY[0].PendingAmount, 1) {
+		t.Fatalf("active orders = %+v, want 1 pending", orders.BUY)
+	}
+	kcs, _ := p.GetCoinBalance("KCS")
+	if !almostEqual(kcs.Balance, 0.999) {
+		t.Errorf("KCS balance = %v, want 0.999", kcs.Balance)
+	}
+
+	market.SetOrdersBook("KCS-BTC", OrdersBook{SELL: [][]float64{{1.05, 5, 5.25}}})
+	if err = p.MatchOrders(); err != nil {
+		t.Fatal(err)
+	}
+	deals, _ := p.ListMergedDealtOrders("KCS-BTC", "", 0, 0, 0, 0)
+	if deals.Total != 2 || deals.Datas[0].DealPrice != 1.05 {
+		t.Errorf("deals = %+v, want 2 deals, the newest at 1.05", deals.Datas)
+	}
+	btc, _ := p.GetCoinBalance("BTC")
+	if !almostEqual(btc.Balance, 10-1-1.05) || btc.FreezeBalance != 0 {
+		t.Errorf("BTC balance = %+v, want %v free", btc, 10-1-1.05)
+	}
+
+	if _, err = p.CreateOrder("KCS-BTC", "SELL", 5, 3); err == nil {
+		t.Error("order exceeding balance is accepted")
+	}
+}
+
+func TestSimulatorSharedLiquidity(t *testing.T) {
+	s := NewSimulator("test", map[string]float64{"BTC": 10})
+	s.FeeRate = 0
+	first, _ := s.CreateOrder("KCS-BTC", "BUY", 1, 2)
+	second, _ := s.CreateOrder("KCS-BTC", "BUY", 1, 2)
+
+	book := OrdersBook{SELL: [][]float64{{1, 3, 3}}}
+	s.MatchBook("KCS-BTC", book)
+	if book.SELL[0][1] != 3 {
+		t.Errorf("book level = %v, caller's book is modified", book.SELL[0])
+	}
+	filled := map[string]float64{}
+	for _, d := range s.Deals() {
+		filled[d.OrderOid] += d.Amount
+	}
+	if filled[first] != 2 || filled[second] != 1 {
+		t.Fatalf("filled = %v, want 2 of the first order and 1 of the second", filled)
+	}
+
+	// The second order takes its pending 1 from the trade, the newer order gets the remainder.
+	third, _ := s.CreateOrder("KCS-BTC", "BUY", 1, 2)
+	s.MatchTrade("KCS-BTC", Trade{Price: 1, Amount: 1.5})
+	filled = map[string]float64{}
+	for _, d := range s.Deals() {
+		filled[d.OrderOid] += d.Amount
+	}
+	if filled[second] != 2 || filled[third] != 0.5 {
+		t.Errorf("filled = %v, want 2 of the second order and 0.5 of the third", filled)
+	}
+	if b := s.Balances(); b["KCS"] != 4.5 || b["BTC"] != 5.5 {
+		t.Errorf("balances = %v, want 4.5 KCS and 5.5 BTC", b)
+	}
+}
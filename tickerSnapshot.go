@@ -0,0 +1,154 @@
+package kucoin
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// TickerSnapshot is the set of tickers of all symbols taken at the same time.
+// Filter and sort methods return new snapshots and don't modify the receiver.
+type TickerSnapshot struct {
+	Time    time.Time
+	Symbols []Symbol
+}
+
+// TickerSnapshot is used to fetch tickers of all symbols at Kucoin at once.
+func (b *Kucoin) TickerSnapshot() (snapshot TickerSnapshot, err error) {
+	symbols, err := b.GetTickers()
+	if err != nil {
+		return
+	}
+	return TickerSnapshot{Time: time.Now(), Symbols: symbols}, nil
+}
+
+// Get returns ticker of symbol.
+func (s TickerSnapshot) Get(symbol string) (Symbol, bool) {
+	symbol = normalizeSymbol(symbol)
+	for _, t := range s.Symbols {
+		if normalizeSymbol(t.Symbol) == symbol {
+			return t, true
+		}
+	}
+	return Symbol{}, false
+}
+
+// Filter returns tickers for which keep returns true.
+func (s TickerSnapshot) Filter(keep func(Symbol) bool) TickerSnapshot {
+	filtered := TickerSnapshot{Time: s.Time}
+	for _, t := range s.Symbols {
+		if keep(t) {
+			filtered.Symbols = append(filtered.Symbols, t)
+		}
+	}
+	return filtered
+}
+
+// Market returns tickers of market, i.e. symbols with given quote coin (CoinTypePair).
+func (s TickerSnapshot) Market(market string) TickerSnapshot {
+	return s.Filter(func(t Symbol) bool {
+		return strings.EqualFold(t.CoinTypePair, market)
+	})
+}
+
+// Trading returns tickers of symbols with enabled trading.
+func (s TickerSnapshot) Trading() TickerSnapshot {
+	return s.Filter(func(t Symbol) bool {
+		return t.Trading
+	})
+}
+
+// ChangeRateBetween returns tickers with ChangeRate in [min, max] range.
+func (s TickerSnapshot) ChangeRateBetween(min, max float64) TickerSnapshot {
+	return s.Filter(func(t Symbol) bool {
+		return t.ChangeRate >= min && t.ChangeRate <= max
+	})
+}
+
+// MinVolValue returns tickers with VolValue not less than min.
+func (s TickerSnapshot) MinVolValue(min float64) TickerSnapshot {
+	return s.Filter(func(t Symbol) bool {
+		return t.VolValue >= min
+	})
+}
+
+// SortBy returns tickers sorted with less function.
+func (s TickerSnapshot) SortBy(less func(a, b Symbol) bool) TickerSnapshot {
+	sorted := TickerSnapshot{Time: s.Time, Symbols: append([]Symbol(nil), s.Symbols...)}
+	sort.SliceStable(sorted.Symbols, func(i, j int) bool {
+		return less(sorted.Symbols[i], sorted.Symbols[j])
+	})
+	return sorted
+}
+
+// SortByChangeRate returns tickers sorted by ChangeRate, the greatest first if desc is true.
+func (s TickerSnapshot) SortByChangeRate(desc bool) TickerSnapshot {
+	return s.SortBy(func(a, b Symbol) bool {
+		if desc {
+			return a.ChangeRate > b.ChangeRate
+		}
+		return a.ChangeRate < b.ChangeRate
+	})
+}
+
+// SortByVolValue returns tickers sorted by VolValue, the greatest first if desc is true.
+func (s TickerSnapshot) SortByVolValue(desc bool) TickerSnapshot {
+	return s.SortBy(func(a, b Symbol) bool {
+		if desc {
+			return a.VolValue > b.VolValue
+		}
+		return a.VolValue < b.VolValue
+	})
+}
+
+// Top returns the first n tickers, n is clamped to [0, number of tickers].
+// Appending to the result doesn't modify tickers of s.
+func (s TickerSnapshot) Top(n int) TickerSnapshot {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(s.Symbols) {
+		n = len(s.Symbols)
+	}
+	return TickerSnapshot{Time: s.Time, Symbols: s.Symbols[:n:n]}
+}
+
+// TickerDiff lists symbols changed between two snapshots.
+type TickerDiff struct {
+	// New symbols are present only in the next snapshot.
+	New []Symbol
+	// Delisted symbols are present only in the previous snapshot.
+	Delisted []Symbol
+	// Halted symbols stopped trading.
+	Halted []Symbol
+	// Resumed symbols started trading again.
+	Resumed []Symbol
+}
+
+// DiffTickerSnapshots compares previous and next snapshots.
+func DiffTickerSnapshots(prev, next TickerSnapshot) (diff TickerDiff) {
+	prevMap := make(map[string]Symbol, len(prev.Symbols))
+	for _, t := range prev.Symbols {
+		prevMap[normalizeSymbol(t.Symbol)] = t
+	}
+	nextMap := make(map[string]bool, len(next.Symbols))
+	for _, t := range next.Symbols {
+		symbol := normalizeSymbol(t.Symbol)
+		nextMap[symbol] = true
+		p, ok := prevMap[symbol]
+		switch {
+		case !ok:
+			diff.New = append(diff.New, t)
+		case p.Trading && !t.Trading:
+			diff.Halted = append(diff.Halted, t)
+		case !p.Trading && t.Trading:
+			diff.Resumed = append(diff.Resumed, t)
+		}
+	}
+	for _, t := range prev.Symbols {
+		if !nextMap[normalizeSymbol(t.Symbol)] {
+			diff.Delisted = append(diff.Delisted, t)
+		}
+	}
+	return
+}
@@ -0,0 +1,35 @@
+package kucoin
+
+import "testing"
+
+func TestTickerSnapshotTop(t *testing.T) {
+	s := TickerSnapshot{Symbols: []Symbol{{Symbol: "KCS-BTC"}, {Symbol: "ETH-BTC"}, {Symbol: "NEO-BTC"}}}
+	tests := []struct {
+		n    int
+		want int
+	}{
+		{-1, 0},
+		{0, 0},
+		{2, 2},
+		{3, 3},
+		{10, 3},
+	}
+	for _, tt := range tests {
+		if got := len(s.Top(tt.n).Symbols); got != tt.want {
+			t.Errorf("Top(%d) returned %d tickers, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestTickerSnapshotTopAppend(t *testing.T) {
+	symbols := make([]Symbol, 3, 4)
+	symbols[0], symbols[1], symbols[2] = Symbol{Symbol: "KCS-BTC"}, Symbol{Symbol: "ETH-BTC"}, Symbol{Symbol: "NEO-BTC"}
+	s := TickerSnapshot{Symbols: symbols}
+	for _, n := range []int{2, 3} {
+		top := s.Top(n)
+		top.Symbols = append(top.Symbols, Symbol{Symbol: "XRB-BTC"})
+		if s.Symbols[2].Symbol != "NEO-BTC" || symbols[:4][3].Symbol != "" {
+			t.Fatalf("append to Top(%d) modified snapshot: %v", n, symbols[:4])
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package kucoin
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Trade represents single public execution of symbol.
+type Trade struct {
+	Time   time.Time
+	Side   string
+	Price  float64
+	Amount float64
+	// Volume is Price * Amount in quote currency.
+	Volume float64
+}
+
+// rawTrades data is array of [timestamp, side, price, amount, volume] arrays.
+type rawTrades struct {
+	Success   bool            `json:"success"`
+	Code      string          `json:"code"`
+	Msg       string          `json:"msg"`
+	Timestamp int64           `json:"timestamp"`
+	Data      [][]interface{} `json:"data"`
+}
+
+func (r rawTrades) trades() ([]Trade, error) {
+	trades := make([]Trade, 0, len(r.Data))
+	for _, row := range r.Data {
+		if len(row) < 5 {
+			return nil, fmt.Errorf("Malformed trade %v", row)
+		}
+		ts, ok1 := row[0].(float64)
+		side, ok2 := row[1].(string)
+		price, ok3 := row[2].(float64)
+		amount, ok4 := row[3].(float64)
+		volume, ok5 := row[4].(float64)
+		if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 {
+			return nil, fmt.Errorf("Malformed trade %v", row)
+		}
+		trades = append(trades, Trade{
+			Time:   msToTime(int64(ts)),
+			Side:   side,
+			Price:  price,
+			Amount: amount,
+			Volume: volume,
+		})
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Time.Before(trades[j].Time)
+	})
+	return trades, nil
+}
+
+// TradePoller polls recent trades of symbol and returns only trades
+// which were not returned by previous calls.
+type TradePoller struct {
+	k      *Kucoin
+	symbol string
+	// Limit is the number of recent trades requested per poll.
+	Limit int
+
+	last time.Time
+	// lastSeen counts returned trades with the time equal to last.
+	lastSeen map[Trade]int
+}
+
+// NewTradePoller returns TradePoller of symbol starting from since.
+// Zero since means that the first Poll returns all recent trades.
+func NewTradePoller(k *Kucoin, symbol string, since time.Time) *TradePoller {
+	return &TradePoller{k: k, symbol: normalizeSymbol(symbol), Limit: 100, last: since, lastSeen: map[Trade]int{}}
+}
+
+// Poll fetches recent trades and returns new trades in chronological order.
+func (p *TradePoller) Poll() (trades []Trade, err error) {
+	all, err := p.k.GetRecentTrades(p.symbol, p.Limit, p.last)
+	if err != nil {
+		return
+	}
+	return p.filter(all), nil
+}
+
+// filter returns trades which were not returned before. Trades have no id,
+// so trades with the same time are told apart by value, and identical trades
+// are told apart by the number of times they occur.
+func (p *TradePoller) filter(all []Trade) (trades []Trade) {
+	occurred := map[Trade]int{}
+	for _, t := range all {
+		if t.Time.Before(p.last) {
+			continue
+		}
+		if t.Time.After(p.last) {
+			p.last = t.Time
+			p.lastSeen = map[Trade]int{}
+			occurred = map[Trade]int{}
+		}
+		occurred[t]++
+		if occurred[t] <= p.lastSeen[t] {
+			continue
+		}
+		p.lastSeen[t] = occurred[t]
+		trades = append(trades, t)
+	}
+	return
+}
@@ -0,0 +1,38 @@
+package kucoin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTradePollerFilter(t *testing.T) {
+	t0 := time.Unix(1500000000, 0)
+	a := Trade{Time: t0, Side: "BUY", Price: 1, Amount: 2, Volume: 2}
+	b := Trade{Time: t0.Add(time.Second), Side: "SELL", Price: 1, Amount: 1, Volume: 1}
+	c := Trade{Time: t0.Add(2 * time.Second), Side: "BUY", Price: 2, Amount: 1, Volume: 2}
+
+	p := &TradePoller{lastSeen: map[Trade]int{}}
+	polls := []struct {
+		all  []Trade
+		want []Trade
+	}{
+		{[]Trade{a, b, b}, []Trade{a, b, b}},
+		// The same identical trades are returned again since last.
+		{[]Trade{b, b}, nil},
+		// Third identical trade arrived in the same millisecond.
+		{[]Trade{b, b, b}, []Trade{b}},
+		{[]Trade{b, b, b, c, c}, []Trade{c, c}},
+		{[]Trade{c}, nil},
+	}
+	for i, poll := range polls {
+		got := p.filter(poll.all)
+		if len(got) != len(poll.want) {
+			t.Fatalf("poll %d: got %d trades, want %d", i, len(got), len(poll.want))
+		}
+		for j := range got {
+			if got[j] != poll.want[j] {
+				t.Errorf("poll %d: trade %d = %+v, want %+v", i, j, got[j], poll.want[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package kucoin
+
+import "context"
+
+// Trader is the set of Kucoin methods used by trading bots:
+// orders, balances and deals history. It is implemented by Kucoin
+// and by PaperTrader, so bots can be tested without risking funds.
+type Trader interface {
+	MarketData
+	CreateOrder(symbol, side string, price, amount float64) (orderOid string, err error)
+	CancelOrder(orderOid, side, symbol string) error
+	CancelAllOrders(symbol, side string) error
+	ListActiveMapOrders(symbol string, side string) (activeMapOrders ActiveMapOrder, err error)
+	GetCoinBalance(c string) (coinBalance CoinBalance, err error)
+	GetBalances(ctx context.Context, nonZero bool) (balances map[string]CoinBalance, err error)
+	ListMergedDealtOrders(symbol, side string, limit, page int, since, before int64) (mergedDealtOrders MergedDealtOrder, err error)
+}
+
+// MarketData is the set of public Kucoin methods PaperTrader reads market from.
+type MarketData interface {
+	OrdersBook(symbol string, group, limit int) (ordersBook OrdersBook, err error)
+	GetSymbol(market string) (symbol Symbol, err error)
+}
+
+// OrdersCanceller is the method DeadMansSwitch cancels orders with.
+// It is implemented by Kucoin and every Trader.
+type OrdersCanceller interface {
+	CancelAllOrders(symbol, side string) error
+}
+
+var (
+	_ Trader = (*Kucoin)(nil)
+	_ Trader = (*PaperTrader)(nil)
+)
@@ -1,7 +1,23 @@
 package kucoin
 
+// Statuses of deposit and withdrawal records.
+const (
+	StatusPending  = "PENDING"
+	StatusFinished = "FINISHED"
+	StatusCancel   = "CANCEL"
+)
+
 // Withdrawal struct represents kucoin data model.
 type Withdrawal struct {
+	Oid       string  `json:"oid"`
+	CoinType  string  `json:"coinType"`
+	Address   string  `json:"address"`
+	Memo      string  `json:"memo"`
+	Amount    float64 `json:"amount"`
+	Fee       float64 `json:"fee"`
+	Status    string  `json:"status"`
+	CreatedAt int64   `json:"createdAt"`
+	UpdatedAt int64   `json:"updatedAt"`
 }
 
 type rawWithdrawal struct {
@@ -0,0 +1,130 @@
+package kucoin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// WithdrawalRequest describes withdrawal to be created at Kucoin.
+// Memo (tag) is required by some coins to identify the receiver.
+type WithdrawalRequest struct {
+	Coin    string
+	Address string
+	Memo    string
+	Amount  float64
+}
+
+// AddressValidator checks format of withdrawal address and memo of specific coin.
+type AddressValidator func(address, memo string) error
+
+// AllowedAddress is an entry of withdrawal address allow-list.
+type AllowedAddress struct {
+	Coin    string `json:"coin"`
+	Address string `json:"address"`
+	Memo    string `json:"memo,omitempty"`
+	Label   string `json:"label,omitempty"`
+}
+
+// AddressAllowList is a client-side list of addresses allowed for withdrawal.
+type AddressAllowList struct {
+	mu      sync.RWMutex
+	entries map[string][]AllowedAddress
+}
+
+// NewAddressAllowList returns allow-list containing given addresses.
+func NewAddressAllowList(addresses ...AllowedAddress) *AddressAllowList {
+	l := &AddressAllowList{entries: make(map[string][]AllowedAddress)}
+	for _, a := range addresses {
+		l.Add(a)
+	}
+	return l
+}
+
+// LoadAddressAllowList reads allow-list from JSON array of AllowedAddress.
+func LoadAddressAllowList(r io.Reader) (*AddressAllowList, error) {
+	var addresses []AllowedAddress
+	if err := json.NewDecoder(r).Decode(&addresses); err != nil {
+		return nil, err
+	}
+	for _, a := range addresses {
+		if len(a.Coin) < 1 || len(a.Address) < 1 {
+			return nil, fmt.Errorf("The allow-list entry %+v has no coin or address", a)
+		}
+	}
+	return NewAddressAllowList(addresses...), nil
+}
+
+// LoadAddressAllowListFile reads allow-list from JSON file.
+func LoadAddressAllowListFile(name string) (*AddressAllowList, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadAddressAllowList(f)
+}
+
+// Add adds address to allow-list.
+func (l *AddressAllowList) Add(a AllowedAddress) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	coin := strings.ToUpper(a.Coin)
+	l.entries[coin] = append(l.entries[coin], a)
+}
+
+// Addresses returns allowed addresses of coin.
+func (l *AddressAllowList) Addresses(coin string) []AllowedAddress {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return append([]AllowedAddress(nil), l.entries[strings.ToUpper(coin)]...)
+}
+
+// Check returns error if address and memo pair is not allowed for coin.
+func (l *AddressAllowList) Check(coin, address, memo string) error {
+	for _, a := range l.Addresses(coin) {
+		if a.Address == address && a.Memo == memo {
+			return nil
+		}
+	}
+	if len(memo) > 0 {
+		return fmt.Errorf("The address %s with memo %s is not allowed for %s", address, memo, strings.ToUpper(coin))
+	}
+	return fmt.Errorf("The address %s is not allowed for %s", address, strings.ToUpper(coin))
+}
+
+// SetWithdrawalAllowList enables checking of withdrawal addresses against allow-list.
+// Nil disables the check.
+func (b *Kucoin) SetWithdrawalAllowList(l *AddressAllowList) {
+	b.allowList = l
+}
+
+// SetAddressValidator sets format validator of withdrawal addresses for coin.
+// Nil removes validator.
+func (b *Kucoin) SetAddressValidator(coin string, v AddressValidator) {
+	coin = strings.ToUpper(coin)
+	if v == nil {
+		delete(b.addressValidators, coin)
+		return
+	}
+	if b.addressValidators == nil {
+		b.addressValidators = make(map[string]AddressValidator)
+	}
+	b.addressValidators[coin] = v
+}
+
+// checkWithdrawalAddress applies address validator and allow-list to request.
+func (b *Kucoin) checkWithdrawalAddress(req WithdrawalRequest) error {
+	if v, ok := b.addressValidators[strings.ToUpper(req.Coin)]; ok {
+		if err := v(req.Address, req.Memo); err != nil {
+			return err
+		}
+	}
+	if b.allowList != nil {
+		return b.allowList.Check(req.Coin, req.Address, req.Memo)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package kucoin
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAddressAllowListCheck(t *testing.T) {
+	l := NewAddressAllowList(
+		AllowedAddress{Coin: "btc", Address: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT"},
+		AllowedAddress{Coin: "XRP", Address: "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", Memo: "123"},
+	)
+	tests := []struct {
+		name    string
+		coin    string
+		address string
+		memo    string
+		allowed bool
+	}{
+		{"exact match", "BTC", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "", true},
+		{"coin case", "Btc", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "", true},
+		{"address of another coin", "ETH", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "", false},
+		{"unknown coin", "NEO", "AK2nJJpJr6o664CWJKi1QRXjqeic2zRp8y", "", false},
+		{"unknown address", "BTC", "1111111111111111111114oLvT2", "", false},
+		{"address case", "BTC", "1boatslrhtknngkdxeeobr76b53lettpyt", "", false},
+		{"memo match", "XRP", "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "123", true},
+		{"memo mismatch", "XRP", "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "124", false},
+		{"memo missing", "XRP", "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "", false},
+		{"entry without memo, request with memo", "BTC", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := l.Check(tt.coin, tt.address, tt.memo)
+			if (err == nil) != tt.allowed {
+				t.Errorf("Check(%s, %s, %q) = %v, allowed %v", tt.coin, tt.address, tt.memo, err, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestLoadAddressAllowList(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{"valid", `[{"coin":"BTC","address":"1abc","label":"cold"},{"coin":"XRP","address":"rabc","memo":"1"}]`, false},
+		{"no coin", `[{"address":"1abc"}]`, true},
+		{"no address", `[{"coin":"BTC","memo":"1"}]`, true},
+		{"malformed", `{"coin":"BTC"}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := LoadAddressAllowList(strings.NewReader(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && l.Check("xrp", "rabc", "1") != nil {
+				t.Error("loaded entry is not allowed")
+			}
+		})
+	}
+}
+
+func TestCheckWithdrawalAddress(t *testing.T) {
+	errFormat := errors.New("bad format")
+	var calls []string
+	k := New("", "")
+	k.SetAddressValidator("btc", func(address, memo string) error {
+		calls = append(calls, address)
+		if !strings.HasPrefix(address, "1") {
+			return errFormat
+		}
+		return nil
+	})
+	k.SetWithdrawalAllowList(NewAddressAllowList(AllowedAddress{Coin: "BTC", Address: "1abc"}))
+
+	// Validator runs before allow-list, so format errors are reported even for unlisted addresses.
+	if err := k.checkWithdrawalAddress(WithdrawalRequest{Coin: "BTC", Address: "xyz"}); err != errFormat {
+		t.Errorf("err = %v, want validator error", err)
+	}
+	if err := k.checkWithdrawalAddress(WithdrawalRequest{Coin: "BTC", Address: "1def"}); err == nil {
+		t.Error("address missing in allow-list is accepted")
+	}
+	if err := k.checkWithdrawalAddress(WithdrawalRequest{Coin: "BTC", Address: "1abc"}); err != nil {
+		t.Errorf("allowed address is rejected: %v", err)
+	}
+	if len(calls) != 3 {
+		t.Errorf("validator is called %d times, want 3", len(calls))
+	}
+
+	k.SetAddressValidator("BTC", nil)
+	k.SetWithdrawalAllowList(nil)
+	if err := k.checkWithdrawalAddress(WithdrawalRequest{Coin: "BTC", Address: "xyz"}); err != nil {
+		t.Errorf("err = %v without validator and allow-list", err)
+	}
+}
@@ -0,0 +1,80 @@
+package kucoin
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// WithdrawalQuote describes fee and net amount of prospective withdrawal.
+type WithdrawalQuote struct {
+	Coin string
+	// Amount is requested amount rounded down to coin precision.
+	Amount float64
+	Fee    float64
+	// Net is the amount received at destination address.
+	Net       float64
+	Precision int
+}
+
+// QuoteWithdrawal is used to calculate fee and net amount of withdrawal
+// according to coin rules at Kucoin.
+func (b *Kucoin) QuoteWithdrawal(coin string, amount float64) (quote WithdrawalQuote, err error) {
+	c, err := b.coinInfo(coin)
+	if err != nil {
+		return
+	}
+	return quoteWithdrawal(c, amount)
+}
+
+// ValidateWithdrawal checks withdrawal against coin rules and available balance
+// before it is submitted. The quote of withdrawal is returned on success.
+func (b *Kucoin) ValidateWithdrawal(coin string, amount float64) (quote WithdrawalQuote, err error) {
+	c, err := b.coinInfo(coin)
+	if err != nil {
+		return
+	}
+	if !c.EnableWithdraw {
+		return quote, fmt.Errorf("Withdrawal of %s is disabled", c.Coin)
+	}
+	if quote, err = quoteWithdrawal(c, amount); err != nil {
+		return
+	}
+	balance, err := b.GetCoinBalance(coin)
+	if err != nil {
+		return
+	}
+	if quote.Amount > balance.Available() {
+		return quote, fmt.Errorf("Withdrawal amount %s is greater than available balance %s of %s",
+			FormatPrecision(quote.Amount, quote.Precision), FormatPrecision(balance.Available(), quote.Precision), c.Coin)
+	}
+	return
+}
+
+// coinInfo returns coin meta data from market cache if it is set, or from Kucoin.
+func (b *Kucoin) coinInfo(coin string) (Coin, error) {
+	if b.market != nil {
+		return b.market.Coin(coin)
+	}
+	return b.GetCoin(coin)
+}
+
+func quoteWithdrawal(c Coin, amount float64) (quote WithdrawalQuote, err error) {
+	quote.Coin = strings.ToUpper(c.Coin)
+	quote.Precision = c.TradePrecision
+	quote.Amount = Round(amount, c.TradePrecision, RoundDown)
+	if quote.Amount <= 0 {
+		return quote, fmt.Errorf("%w: withdrawal amount %v", ErrBelowMinimum, amount)
+	}
+	if quote.Amount < c.WithdrawMinAmount {
+		return quote, fmt.Errorf("%w: withdrawal amount %s, minimum %v",
+			ErrBelowMinimum, FormatPrecision(quote.Amount, quote.Precision), c.WithdrawMinAmount)
+	}
+	quote.Fee = math.Max(c.WithdrawMinFee, quote.Amount*c.WithdrawFeeRate)
+	quote.Net = Round(quote.Amount-quote.Fee, c.TradePrecision, RoundDown)
+	if quote.Net <= 0 {
+		return quote, fmt.Errorf("%w: withdrawal amount %s doesn't cover fee %v",
+			ErrBelowMinimum, FormatPrecision(quote.Amount, quote.Precision), quote.Fee)
+	}
+	return
+}
@@ -0,0 +1,115 @@
+package kucoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrWithdrawalNotFound is returned when tracked withdrawal is not found in account history,
+// e.g. because of wrong oid or coin.
+var ErrWithdrawalNotFound = errors.New("Withdrawal is not found")
+
+// WithdrawalTracker follows withdrawal through account history records
+// from PENDING to FINISHED or CANCEL status.
+type WithdrawalTracker struct {
+	k    *Kucoin
+	coin string
+	oid  string
+
+	// Record is the last found account history record of withdrawal.
+	Record AccountRecord
+}
+
+// NewWithdrawalTracker returns tracker of withdrawal with oid,
+// as returned by CreateWithdrawalApply.
+func NewWithdrawalTracker(k *Kucoin, coin, oid string) *WithdrawalTracker {
+	return &WithdrawalTracker{k: k, coin: strings.ToUpper(coin), oid: oid}
+}
+
+// Status returns last known status of withdrawal.
+func (t *WithdrawalTracker) Status() string {
+	return t.Record.Status
+}
+
+// OuterTxid returns transaction id in the outer wallet, when withdrawal is sent.
+func (t *WithdrawalTracker) OuterTxid() string {
+	return t.Record.OuterTxid()
+}
+
+// Done reports whether withdrawal reached final status.
+func (t *WithdrawalTracker) Done() bool {
+	return t.Record.Status == StatusFinished || t.Record.Status == StatusCancel
+}
+
+// Poll looks up withdrawal in account history and updates Record.
+// PENDING records are checked first, so withdrawal which is finished between
+// requests is still found among final records. Once withdrawal is done,
+// its Record is returned without requests.
+// Error is returned if withdrawal is not found in any status.
+func (t *WithdrawalTracker) Poll() (record AccountRecord, err error) {
+	if t.Done() {
+		return t.Record, nil
+	}
+	for _, status := range []string{StatusPending, StatusFinished, StatusCancel} {
+		var found bool
+		if record, found, err = t.find(status); err != nil || found {
+			return
+		}
+	}
+	return record, fmt.Errorf("%w: %s of %s", ErrWithdrawalNotFound, t.oid, t.coin)
+}
+
+// find scans records of status, the newest first. Once the withdrawal has been
+// found, scanning stops at records created before it.
+func (t *WithdrawalTracker) find(status string) (record AccountRecord, found bool, err error) {
+	for page := 1; ; page++ {
+		var history AccountHistory
+		history, err = t.k.AccountHistory(t.coin, "WITHDRAW", status, 100, page)
+		if err != nil {
+			return
+		}
+		for _, r := range history.Datas {
+			if r.Oid == t.oid {
+				t.Record = r
+				return r, true, nil
+			}
+		}
+		n := len(history.Datas)
+		if n == 0 || page >= history.PageNos ||
+			(t.Record.CreatedAt > 0 && history.Datas[n-1].CreatedAt < t.Record.CreatedAt) {
+			return
+		}
+	}
+}
+
+// Wait polls account history with interval until withdrawal is done or ctx is cancelled.
+// ErrWithdrawalNotFound is returned immediately. Other Poll errors, e.g. network
+// failures, are retried, and the last of them is reported together with ctx error.
+func (t *WithdrawalTracker) Wait(ctx context.Context, interval time.Duration) (AccountRecord, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var lastErr error
+	for {
+		record, err := t.Poll()
+		if errors.Is(err, ErrWithdrawalNotFound) {
+			return record, err
+		}
+		if err == nil && t.Done() {
+			return record, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return t.Record, fmt.Errorf("%w, last poll error: %v", ctx.Err(), lastErr)
+			}
+			return t.Record, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
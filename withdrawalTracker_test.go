@@ -0,0 +1,33 @@
+package kucoin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithdrawalTrackerWait(t *testing.T) {
+	k := newTestKucoin(func(r *http.Request) string {
+		return `{"success":true,"data":{"datas":[{"oid":"w1","status":"PENDING","createdAt":1}],"pageNos":1}}`
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := NewWithdrawalTracker(k, "kcs", "unknown").Wait(ctx, time.Millisecond)
+	if !errors.Is(err, ErrWithdrawalNotFound) {
+		t.Errorf("err = %v, want ErrWithdrawalNotFound without waiting for ctx", err)
+	}
+
+	k = newTestKucoin(func(r *http.Request) string {
+		return `{"error":{"message":"Invalid API key"}}`
+	})
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = NewWithdrawalTracker(k, "KCS", "w1").Wait(ctx, time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "Invalid API key") {
+		t.Errorf("err = %v, want deadline error with the last poll error", err)
+	}
+}